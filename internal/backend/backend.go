@@ -97,6 +97,25 @@ func GetSignParamsFromData(data *framework.FieldData) (serviceName, hashInput, a
 	return
 }
 
+// GetAddressParamsFromData извлекает name и address для путей, которые подписывают не готовый hash
+func GetAddressParamsFromData(data *framework.FieldData) (serviceName, address string, err error) {
+	svc, ok := data.Get("name").(string)
+	if !ok || svc == "" {
+		err = fmt.Errorf("missing or invalid 'name' field: %v", data.Get("name"))
+		return
+	}
+
+	addr, ok := data.Get("address").(string)
+	if !ok || addr == "" {
+		err = fmt.Errorf("missing or invalid 'address' field: %v", data.Get("address"))
+		return
+	}
+
+	serviceName = svc
+	address = addr
+	return
+}
+
 func KeyManagerExistenceCheck(chain config.ChainType) func(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
 	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
 		name := data.Get("name").(string)
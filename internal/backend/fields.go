@@ -29,6 +29,42 @@ var DefaultCrudOperations = map[string]*framework.FieldSchema{
 		Description: "(default random key) only for vault success operations",
 		Default:     "",
 	},
+	"hd": {
+		Type:        framework.TypeBool,
+		Description: "(Optional) Create the key-manager as an HD wallet from a Vault-generated BIP-39 mnemonic",
+		Default:     false,
+	},
+	"mnemonic": {
+		Type:        framework.TypeString,
+		Description: "(Optional) BIP-39 mnemonic to import; new keys are derived from it along the chain's BIP-44 path",
+		Default:     "",
+	},
+	"passphrase": {
+		Type:        framework.TypeString,
+		Description: "(Optional) BIP-39 passphrase used together with the mnemonic",
+		Default:     "",
+	},
+}
+
+// CrudFields возвращает DefaultCrudOperations, дополненные chain-specific полями
+func CrudFields(extra map[string]*framework.FieldSchema) map[string]*framework.FieldSchema {
+	return mergeFields(DefaultCrudOperations, extra)
+}
+
+// SignFields возвращает DefaultSignOperation, дополненные chain-specific полями
+func SignFields(extra map[string]*framework.FieldSchema) map[string]*framework.FieldSchema {
+	return mergeFields(DefaultSignOperation, extra)
+}
+
+func mergeFields(base, extra map[string]*framework.FieldSchema) map[string]*framework.FieldSchema {
+	fields := make(map[string]*framework.FieldSchema, len(base)+len(extra))
+	for k, v := range base {
+		fields[k] = v
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	return fields
 }
 
 var DefaultUpdateOperations = map[string]*framework.FieldSchema{
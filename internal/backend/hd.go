@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	"github.com/hashicorp/vault/sdk/framework"
+)
+
+var ErrPrivateKeyWithMnemonic = errors.New("private_key cannot be imported into an HD key-manager")
+
+// SetupMnemonic attaches a mnemonic to a key-manager when the request asks for HD mode.
+// The mnemonic is returned only when it was generated by Vault, so the caller can hand it out once for backup.
+func SetupMnemonic(km *types.KeyManager, data *framework.FieldData) (string, error) {
+	isHD, _ := data.Get("hd").(bool)
+	mnemonic, _ := data.Get("mnemonic").(string)
+	passphrase, _ := data.Get("passphrase").(string)
+
+	if !isHD && mnemonic == "" {
+		return "", nil
+	}
+
+	if mnemonic != "" {
+		normalized, err := hd.NormalizeMnemonic(mnemonic)
+		if err != nil {
+			return "", err
+		}
+		mnemonic = normalized
+	}
+
+	if km.Mnemonic != "" {
+		// повторный вызов для HD-сервиса допустим только с той же мнемоникой
+		if mnemonic != "" && (mnemonic != km.Mnemonic || passphrase != km.Passphrase) {
+			return "", fmt.Errorf("key-manager %s already has a different mnemonic", km.ServiceName)
+		}
+		return "", nil
+	}
+
+	if len(km.KeyPairs) > 0 {
+		return "", fmt.Errorf("key-manager %s already holds non-HD keys", km.ServiceName)
+	}
+
+	generated := ""
+	if mnemonic == "" {
+		var err error
+		mnemonic, err = hd.NewMnemonic()
+		if err != nil {
+			return "", err
+		}
+		generated = mnemonic
+	}
+
+	km.Mnemonic = mnemonic
+	km.Passphrase = passphrase
+	return generated, nil
+}
+
+// NextDerivationPath formats the path of the next HD key; format must contain a single %d for the index.
+// Indexes are counted per format, so every BIP-44/49/84/86 purpose keeps its own gap-free sequence.
+func NextDerivationPath(km *types.KeyManager, format string) (string, uint32) {
+	var next uint32
+	for _, kp := range km.KeyPairs {
+		if kp.DerivationIndex == nil || *kp.DerivationIndex < next {
+			continue
+		}
+		if kp.DerivationPath == fmt.Sprintf(format, *kp.DerivationIndex) {
+			next = *kp.DerivationIndex + 1
+		}
+	}
+	return fmt.Sprintf(format, next), next
+}
+
+// AddHDResponseData adds the derivation details of kp to a create response.
+func AddHDResponseData(resp map[string]interface{}, kp *types.KeyPair, generatedMnemonic string) {
+	if kp.DerivationPath != "" {
+		resp["derivation_path"] = kp.DerivationPath
+	}
+	if kp.DerivationIndex != nil {
+		resp["derivation_index"] = *kp.DerivationIndex
+	}
+	if generatedMnemonic != "" {
+		resp["mnemonic"] = generatedMnemonic
+	}
+}
@@ -0,0 +1,35 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// NetworkMainnet — сеть по умолчанию; её же имеют записи, созданные до появления настройки network
+const NetworkMainnet = "mainnet"
+
+// SetupNetwork fixes the network of a key-manager. A new service takes the requested network,
+// then the mount default from config/<chain>, then mainnet; an existing one keeps its network
+// and rejects a different request, because all addresses of a service must belong to the same network.
+func SetupNetwork(km *types.KeyManager, requested, mountDefault string) (string, error) {
+	current := km.Network
+	if current == "" && len(km.KeyPairs) > 0 {
+		current = NetworkMainnet
+	}
+	if current != "" {
+		if requested != "" && requested != current {
+			return "", fmt.Errorf("key-manager %s belongs to network %s, not %s", km.ServiceName, current, requested)
+		}
+		km.Network = current
+		return current, nil
+	}
+	if requested == "" {
+		requested = mountDefault
+	}
+	if requested == "" {
+		requested = NetworkMainnet
+	}
+	km.Network = requested
+	return requested, nil
+}
@@ -0,0 +1,207 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+var configFields = map[string]*framework.FieldSchema{
+	"network": {
+		Type:        framework.TypeString,
+		Description: "Default network for new services (btc, doge, ltc, ada, dot).",
+	},
+	"address_type": {
+		Type:        framework.TypeString,
+		Description: "Default address type for new keys (btc, ltc, ada).",
+	},
+	"wallet_version": {
+		Type:        framework.TypeString,
+		Description: "Default wallet contract version for new keys (ton).",
+	},
+	"workchain": {
+		Type:        framework.TypeInt,
+		Description: "Default workchain for new keys (ton).",
+	},
+	"chain_id": {
+		Type:        framework.TypeInt64,
+		Description: "Default EIP-155 chain id for transaction signing (eth).",
+	},
+}
+
+var configHelpSynopsis = "Mount-level defaults of a chain: network, address type, TON wallet, EVM chain id."
+
+var configHelpDescription = `
+    GET    config         - defaults of every configured chain
+    GET    config/<chain> - defaults of a chain
+    POST   config/<chain> - set defaults (only the given fields change)
+    DELETE config/<chain> - reset to built-in defaults
+`
+
+func PathConfig() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathConfig(),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: readAllConfigs,
+			},
+		},
+		HelpSynopsis:    configHelpSynopsis,
+		HelpDescription: configHelpDescription,
+	}
+}
+
+func PathConfigChain(chain config.ChainType) *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathConfigChain(chain),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: func(ctx context.Context, req *logical.Request, _ *framework.FieldData) (*logical.Response, error) {
+					return readConfig(ctx, req, chain)
+				},
+			},
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+					return writeConfig(ctx, req, data, chain)
+				},
+			},
+			logical.DeleteOperation: &framework.PathOperation{
+				Callback: func(ctx context.Context, req *logical.Request, _ *framework.FieldData) (*logical.Response, error) {
+					return nil, req.Storage.Delete(ctx, config.GetConfigStoragePath(chain))
+				},
+			},
+		},
+		Fields:          configFields,
+		HelpSynopsis:    configHelpSynopsis,
+		HelpDescription: configHelpDescription,
+	}
+}
+
+// GetChainConfig returns the stored defaults of a chain, or an empty config when none are set.
+func GetChainConfig(ctx context.Context, storage logical.Storage, chain config.ChainType) (*types.ChainConfig, error) {
+	entry, err := storage.Get(ctx, config.GetConfigStoragePath(chain))
+	if err != nil {
+		return nil, err
+	}
+	cfg := &types.ChainConfig{}
+	if entry == nil {
+		return cfg, nil
+	}
+	if err := entry.DecodeJSON(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// CheckConfigFields rejects settings the chain does not use, so a typo in a chain name does not go unnoticed.
+func CheckConfigFields(cfg *types.ChainConfig, allowed ...string) error {
+	set := map[string]bool{
+		"network":        cfg.Network != "",
+		"address_type":   cfg.AddressType != "",
+		"wallet_version": cfg.WalletVersion != "",
+		"workchain":      cfg.Workchain != nil,
+		"chain_id":       cfg.ChainID != 0,
+	}
+	for _, name := range allowed {
+		delete(set, name)
+	}
+	for name, isSet := range set {
+		if isSet {
+			return fmt.Errorf("%s is not supported by this chain", name)
+		}
+	}
+	return nil
+}
+
+func readAllConfigs(ctx context.Context, req *logical.Request, _ *framework.FieldData) (*logical.Response, error) {
+	out := map[string]interface{}{}
+	for _, chain := range config.AllChains {
+		cfg, err := GetChainConfig(ctx, req.Storage, chain)
+		if err != nil {
+			return nil, err
+		}
+		if data := configResponseData(cfg); len(data) > 0 {
+			out[string(chain)] = data
+		}
+	}
+	return &logical.Response{Data: out}, nil
+}
+
+func readConfig(ctx context.Context, req *logical.Request, chain config.ChainType) (*logical.Response, error) {
+	cfg, err := GetChainConfig(ctx, req.Storage, chain)
+	if err != nil {
+		return nil, err
+	}
+	return &logical.Response{Data: configResponseData(cfg)}, nil
+}
+
+func writeConfig(ctx context.Context, req *logical.Request, data *framework.FieldData, chain config.ChainType) (*logical.Response, error) {
+	cfg, err := GetChainConfig(ctx, req.Storage, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// меняются только переданные поля
+	if v, ok := data.GetOk("network"); ok {
+		cfg.Network = v.(string)
+	}
+	if v, ok := data.GetOk("address_type"); ok {
+		cfg.AddressType = v.(string)
+	}
+	if v, ok := data.GetOk("wallet_version"); ok {
+		cfg.WalletVersion = v.(string)
+	}
+	if v, ok := data.GetOk("workchain"); ok {
+		workchain := int32(v.(int))
+		cfg.Workchain = &workchain
+	}
+	if v, ok := data.GetOk("chain_id"); ok {
+		chainID := v.(int64)
+		if chainID < 0 {
+			return nil, fmt.Errorf("chain_id must be positive")
+		}
+		cfg.ChainID = uint64(chainID)
+	}
+
+	if ep, ok := registry[chain]; ok && ep.ValidateConfig != nil {
+		err = ep.ValidateConfig(cfg)
+	} else {
+		err = CheckConfigFields(cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", chain, err)
+	}
+
+	entry, err := logical.StorageEntryJSON(config.GetConfigStoragePath(chain), cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+	return &logical.Response{Data: configResponseData(cfg)}, nil
+}
+
+func configResponseData(cfg *types.ChainConfig) map[string]interface{} {
+	out := map[string]interface{}{}
+	if cfg.Network != "" {
+		out["network"] = cfg.Network
+	}
+	if cfg.AddressType != "" {
+		out["address_type"] = cfg.AddressType
+	}
+	if cfg.WalletVersion != "" {
+		out["wallet_version"] = cfg.WalletVersion
+	}
+	if cfg.Workchain != nil {
+		out["workchain"] = *cfg.Workchain
+	}
+	if cfg.ChainID != 0 {
+		out["chain_id"] = cfg.ChainID
+	}
+	return out
+}
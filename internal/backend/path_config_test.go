@@ -0,0 +1,75 @@
+package backend_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WriteReadDelete(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "config/btc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"network": "signet", "address_type": "segwit"}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	// only the given fields change
+	req = logical.TestRequest(t, logical.UpdateOperation, "config/btc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"address_type": "legacy"}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"network": "signet", "address_type": "legacy"}, resp.Data)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "config/ton")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"workchain": -1}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.ReadOperation, "config")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, resp.Data, 2)
+	assert.Equal(t, int32(-1), resp.Data["ton"].(map[string]interface{})["workchain"])
+
+	req = logical.TestRequest(t, logical.DeleteOperation, "config/btc")
+	req.Storage = storage
+	_, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.ReadOperation, "config/btc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Data)
+}
+
+func TestConfig_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	cases := map[string]map[string]interface{}{
+		"config/btc":  {"network": "testnet9"},
+		"config/doge": {"address_type": "segwit"},
+		"config/eth":  {"network": "testnet"},
+		"config/ton":  {"workchain": 5},
+		"config/sol":  {"chain_id": 1},
+	}
+	for path, fields := range cases {
+		t.Run(strings.TrimPrefix(path, "config/"), func(t *testing.T) {
+			req := logical.TestRequest(t, logical.UpdateOperation, path)
+			req.Storage = storage
+			req.Data = fields
+			_, err := b.HandleRequest(context.Background(), req)
+			require.Error(t, err)
+		})
+	}
+}
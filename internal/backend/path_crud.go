@@ -72,15 +72,55 @@ func readKeyManager(
 		if kp.ExternalData != nil {
 			pair["external_data"] = kp.ExternalData
 		}
+		if kp.DerivationPath != "" {
+			pair["derivation_path"] = kp.DerivationPath
+		}
+		if kp.DerivationIndex != nil {
+			pair["derivation_index"] = *kp.DerivationIndex
+		}
+		if kp.AddressType != "" {
+			pair["address_type"] = kp.AddressType
+		}
+		if kp.AddressMode != "" {
+			pair["address_mode"] = kp.AddressMode
+		}
+		if kp.WalletVersion != "" {
+			pair["wallet_version"] = kp.WalletVersion
+		}
+		if kp.Workchain != nil {
+			pair["workchain"] = *kp.Workchain
+		}
+		if kp.KeyType != "" {
+			pair["key_type"] = kp.KeyType
+		}
+		if kp.SubwalletID != nil {
+			pair["subwallet_id"] = *kp.SubwalletID
+		}
+		if kp.Bounceable != nil {
+			pair["bounceable"] = *kp.Bounceable
+		}
+		if kp.Testnet {
+			pair["testnet"] = true
+		}
+		if kp.HighloadTimeout != 0 {
+			pair["highload_timeout"] = kp.HighloadTimeout
+		}
 		pairs[i] = pair
 	}
 
-	return &logical.Response{
-		Data: map[string]interface{}{
-			"service_name": serviceName,
-			"key_pairs":    pairs,
-		},
-	}, nil
+	respData := map[string]interface{}{
+		"service_name": serviceName,
+		"key_pairs":    pairs,
+		"hd":           keyManager.Mnemonic != "",
+	}
+	if keyManager.Network != "" {
+		respData["network"] = keyManager.Network
+	}
+	if keyManager.HRP != "" {
+		respData["hrp"] = keyManager.HRP
+	}
+
+	return &logical.Response{Data: respData}, nil
 }
 
 func WrapperDeleteKeyManager(chain config.ChainType) func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
@@ -2,13 +2,18 @@ package backend
 
 import (
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
 	"github.com/hashicorp/vault/sdk/framework"
 )
 
 // Endpoints описывает пару CRUD/Sign для одной монеты
+// и дополнительные chain-specific эндпоинты (sign-tx и т.п.)
 type Endpoints struct {
-	Crud func() *framework.Path
-	Sign func() *framework.Path
+	Crud  func() *framework.Path
+	Sign  func() *framework.Path
+	Extra []func() *framework.Path
+	// ValidateConfig проверяет config/<chain>; без него монета не принимает настроек
+	ValidateConfig func(cfg *types.ChainConfig) error
 }
 
 // registry хранит зарегистрированные эндпоинты
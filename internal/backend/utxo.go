@@ -0,0 +1,134 @@
+package backend
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// UTXOKeySpec describes how a secp256k1 UTXO chain (btc, doge, ltc) derives, imports and encodes a key.
+type UTXOKeySpec struct {
+	Chain   config.ChainType
+	Network string
+	Params  *chaincfg.Params
+	// PathFormat — HD путь с единственным %d для индекса адреса
+	PathFormat string
+	// Address кодирует адрес нужного типа из публичного ключа
+	Address func(pub *btcec.PublicKey) (string, error)
+	// WIFForNet проверяет сеть WIF; nil — wif.IsForNet(Params)
+	WIFForNet func(wif *btcutil.WIF) bool
+	// AddressType и AddressMode сохраняются в KeyPair и возвращаются, если заданы
+	AddressType string
+	AddressMode string
+}
+
+// LookupNetwork returns the chain params of network; an empty value is mainnet.
+func LookupNetwork(networks map[string]*chaincfg.Params, network string) (*chaincfg.Params, error) {
+	if network == "" {
+		network = NetworkMainnet
+	}
+	params, ok := networks[network]
+	if !ok {
+		return nil, fmt.Errorf("unsupported network %q", network)
+	}
+	return params, nil
+}
+
+// CreateUTXOKeyPair derives the next HD key, imports privateKey (WIF of the service network or 32-byte hex)
+// or generates a new key, appends it to km, stores km and returns the create response.
+func CreateUTXOKeyPair(
+	ctx context.Context,
+	req *logical.Request,
+	km *types.KeyManager,
+	privateKey, generatedMnemonic string,
+	spec UTXOKeySpec,
+) (*logical.Response, error) {
+	var privKey *btcec.PrivateKey
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privateKey != "" {
+			return nil, ErrPrivateKeyWithMnemonic
+		}
+		derivationPath, derivationIndex = NextDerivationPath(km, spec.PathFormat)
+		derived, err := hd.DeriveSecp256k1(km.Mnemonic, km.Passphrase, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		privKey, _ = btcec.PrivKeyFromBytes(derived)
+	} else if privateKey != "" {
+		var err error
+		if privKey, err = parseUTXOPrivateKey(privateKey, spec); err != nil {
+			return nil, err
+		}
+	} else {
+		privKey, _ = btcec.NewPrivateKey()
+	}
+
+	pubKey := privKey.PubKey()
+	address, err := spec.Address(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	kp := &types.KeyPair{
+		PrivateKey:  hex.EncodeToString(privKey.Serialize()),
+		PublicKey:   hex.EncodeToString(pubKey.SerializeCompressed()),
+		Address:     address,
+		AddressType: spec.AddressType,
+		AddressMode: spec.AddressMode,
+	}
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
+	}
+	km.KeyPairs = append(km.KeyPairs, kp)
+
+	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(spec.Chain, km.ServiceName), km)
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	respData := map[string]interface{}{
+		"service_name": km.ServiceName,
+		"address":      kp.Address,
+		"public_key":   kp.PublicKey,
+		"network":      spec.Network,
+	}
+	if kp.AddressType != "" {
+		respData["address_type"] = kp.AddressType
+	}
+	if kp.AddressMode != "" {
+		respData["address_mode"] = kp.AddressMode
+	}
+	AddHDResponseData(respData, kp, generatedMnemonic)
+	return &logical.Response{Data: respData}, nil
+}
+
+// parseUTXOPrivateKey принимает WIF сети сервиса или 32-байтовый hex
+func parseUTXOPrivateKey(input string, spec UTXOKeySpec) (*btcec.PrivateKey, error) {
+	if wif, err := btcutil.DecodeWIF(input); err == nil {
+		forNet := wif.IsForNet(spec.Params)
+		if spec.WIFForNet != nil {
+			forNet = spec.WIFForNet(wif)
+		}
+		if !forNet {
+			return nil, fmt.Errorf("private key WIF does not belong to network %s", spec.Network)
+		}
+		return wif.PrivKey, nil
+	}
+	if bs, err := hex.DecodeString(strings.TrimPrefix(input, "0x")); err == nil && len(bs) == 32 {
+		privKey, _ := btcec.PrivKeyFromBytes(bs)
+		return privKey, nil
+	}
+	return nil, fmt.Errorf("invalid private key")
+}
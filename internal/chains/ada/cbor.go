@@ -0,0 +1,172 @@
+package ada
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Основные типы CBOR (RFC 8949)
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborArray  = 4
+	cborMap    = 5
+	cborTag    = 6
+	cborSimple = 7
+)
+
+// cborIndefinite — additional info 31: элементы до break (0xff)
+const (
+	cborIndefinite = 31
+	cborBreak      = 0xff
+)
+
+// cborMaxDepth ограничивает вложенность, чтобы разбор не исчерпал стек
+const cborMaxDepth = 64
+
+// cborSetTag — тег 258 (множество) перед inputs в Conway-эре
+const cborSetTag = 258
+
+var errCBORTruncated = errors.New("truncated CBOR")
+
+// cborHead разбирает заголовок элемента: основной тип, аргумент и длину заголовка
+func cborHead(b []byte) (major byte, arg uint64, n int, err error) {
+	if len(b) == 0 {
+		return 0, 0, 0, errCBORTruncated
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, uint64(info), 1, nil
+	case info == 24:
+		if len(b) < 2 {
+			return 0, 0, 0, errCBORTruncated
+		}
+		return major, uint64(b[1]), 2, nil
+	case info == 25:
+		if len(b) < 3 {
+			return 0, 0, 0, errCBORTruncated
+		}
+		return major, uint64(binary.BigEndian.Uint16(b[1:])), 3, nil
+	case info == 26:
+		if len(b) < 5 {
+			return 0, 0, 0, errCBORTruncated
+		}
+		return major, uint64(binary.BigEndian.Uint32(b[1:])), 5, nil
+	case info == 27:
+		if len(b) < 9 {
+			return 0, 0, 0, errCBORTruncated
+		}
+		return major, binary.BigEndian.Uint64(b[1:]), 9, nil
+	case info == cborIndefinite && major >= cborBytes && major <= cborMap:
+		return major, 0, 1, nil
+	}
+	return 0, 0, 0, fmt.Errorf("invalid CBOR header 0x%02x", b[0])
+}
+
+// cborItemLen возвращает длину первого элемента b вместе с вложенными
+func cborItemLen(b []byte, depth int) (int, error) {
+	if depth > cborMaxDepth {
+		return 0, errors.New("CBOR nesting is too deep")
+	}
+	major, arg, n, err := cborHead(b)
+	if err != nil {
+		return 0, err
+	}
+	indefinite := b[0]&0x1f == cborIndefinite
+
+	switch major {
+	case cborUint, cborNegInt, cborSimple:
+		return n, nil
+	case cborBytes, cborText:
+		if indefinite {
+			return cborItemsUntilBreak(b, n, depth)
+		}
+		if arg > uint64(len(b)-n) {
+			return 0, errCBORTruncated
+		}
+		return n + int(arg), nil
+	case cborTag:
+		size, err := cborItemLen(b[n:], depth+1)
+		return n + size, err
+	}
+
+	// массив или map: у map вдвое больше элементов
+	if indefinite {
+		return cborItemsUntilBreak(b, n, depth)
+	}
+	items := arg
+	if major == cborMap {
+		if items > uint64(len(b)) {
+			return 0, errCBORTruncated
+		}
+		items *= 2
+	}
+	for ; items > 0; items-- {
+		if n >= len(b) {
+			return 0, errCBORTruncated
+		}
+		size, err := cborItemLen(b[n:], depth+1)
+		if err != nil {
+			return 0, err
+		}
+		n += size
+	}
+	return n, nil
+}
+
+func cborItemsUntilBreak(b []byte, n int, depth int) (int, error) {
+	for {
+		if n >= len(b) {
+			return 0, errCBORTruncated
+		}
+		if b[n] == cborBreak {
+			return n + 1, nil
+		}
+		size, err := cborItemLen(b[n:], depth+1)
+		if err != nil {
+			return 0, err
+		}
+		n += size
+	}
+}
+
+// cborCount возвращает число элементов массива (тег 258 пропускается); -1 — неопределённая длина
+func cborCount(b []byte) (int, error) {
+	major, arg, n, err := cborHead(b)
+	if err != nil {
+		return 0, err
+	}
+	if major == cborTag && arg == cborSetTag {
+		return cborCount(b[n:])
+	}
+	if major != cborArray {
+		return 0, errors.New("expected a CBOR array")
+	}
+	if b[0]&0x1f == cborIndefinite {
+		return -1, nil
+	}
+	return int(arg), nil
+}
+
+// cborAppendHead дописывает заголовок с минимальной длиной аргумента
+func cborAppendHead(b []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(b, major<<5|byte(arg))
+	case arg <= 0xff:
+		return append(b, major<<5|24, byte(arg))
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, major<<5|25), uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, major<<5|26), uint32(arg))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major<<5|27), arg)
+}
+
+func cborAppendBytes(b []byte, value []byte) []byte {
+	return append(cborAppendHead(b, cborBytes, uint64(len(value))), value...)
+}
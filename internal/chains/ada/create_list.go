@@ -0,0 +1,203 @@
+package ada
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// PathCrud registers CRUD operations for Cardano key-managers
+func PathCrud() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathCrud(config.Chain.ADA),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.CreateOperation: &framework.PathOperation{
+				Callback: createKeyManager,
+			},
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: backend.WrapperReadKeyManager(config.Chain.ADA),
+			},
+			logical.DeleteOperation: &framework.PathOperation{
+				Callback: backend.WrapperDeleteKeyManager(config.Chain.ADA),
+			},
+		},
+		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.ADA),
+		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
+		HelpDescription: backend.DefaultHelpDescriptionCreateList,
+		Fields:          createFields,
+	}
+}
+
+var createFields = backend.CrudFields(map[string]*framework.FieldSchema{
+	"network": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Network of the service: mainnet or preprod. Defaults to config/ada, then mainnet. Fixed on the first key.",
+	},
+	"address_type": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Shelley address type: base (payment + stake key, HD services only) or enterprise. Defaults to config/ada, then base for HD services and enterprise otherwise",
+	},
+})
+
+// createKeyManager handles POST /key-managers/ada
+func createKeyManager(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	serviceName, ok := data.Get("name").(string)
+	if !ok || serviceName == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	// optional private key: 32-byte ed25519 seed or 64-byte extended key kL || kR, hex
+	privInput, ok := data.Get("private_key").(string)
+	if !ok {
+		return nil, fmt.Errorf("private_key must be a string")
+	}
+	privInput = strings.TrimSpace(privInput)
+
+	cfg, err := backend.GetChainConfig(ctx, req.Storage, config.Chain.ADA)
+	if err != nil {
+		return nil, err
+	}
+	requestedType := strings.TrimSpace(data.Get("address_type").(string))
+	if requestedType == "" {
+		requestedType = cfg.AddressType
+	}
+	if requestedType != "" {
+		if _, err := parseAddressType(requestedType); err != nil {
+			return nil, err
+		}
+	}
+	requestedNetwork := strings.TrimSpace(data.Get("network").(string))
+	if _, err := getNetworkParams(requestedNetwork); err != nil {
+		return nil, err
+	}
+
+	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.ADA, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		km = &types.KeyManager{ServiceName: serviceName}
+	}
+
+	network, err := backend.SetupNetwork(km, requestedNetwork, cfg.Network)
+	if err != nil {
+		return nil, err
+	}
+	params, err := getNetworkParams(network)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// base-адресу нужен ключ стейкинга, он есть только у HD сервиса
+	addrType := requestedType
+	if addrType == "" {
+		addrType = AddressTypeEnterprise
+		if km.Mnemonic != "" {
+			addrType = AddressTypeBase
+		}
+	}
+	if addrType == AddressTypeBase && km.Mnemonic == "" {
+		return nil, fmt.Errorf("base addresses need an HD service with a stake key, use address_type=enterprise")
+	}
+
+	// derive, decode or generate the extended key kL || kR
+	var extended, stakePub []byte
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privInput != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormat)
+		extended, err = hd.DeriveIcarus(km.Mnemonic, km.Passphrase, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		if addrType == AddressTypeBase {
+			stakeKey, err := hd.DeriveIcarus(km.Mnemonic, km.Passphrase, hdStakePath)
+			if err != nil {
+				return nil, err
+			}
+			stakePub = hd.Ed25519ExtendedPublicKey(stakeKey[:32])
+			zero(stakeKey)
+		}
+	} else if privInput != "" {
+		bs, err := hex.DecodeString(strings.TrimPrefix(privInput, "0x"))
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("invalid private key")
+		case len(bs) == 32:
+			extended = extendedFromSeed(bs)
+		case len(bs) == 64 && bs[0]&0x07 == 0:
+			// kL расширенного ключа кратен 8 (cofactor очищен)
+			extended = bs
+		default:
+			return nil, fmt.Errorf("invalid private key")
+		}
+	} else {
+		seed := make([]byte, 32)
+		if _, err := rand.Read(seed); err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+		extended = extendedFromSeed(seed)
+		zero(seed)
+	}
+
+	pub := hd.Ed25519ExtendedPublicKey(extended[:32])
+	address, err := DeriveAddress(addrType, params, pub, stakePub)
+	if err != nil {
+		return nil, err
+	}
+
+	kp := &types.KeyPair{
+		PrivateKey:  hex.EncodeToString(extended),
+		PublicKey:   hex.EncodeToString(pub),
+		Address:     address,
+		AddressType: addrType,
+	}
+	zero(extended)
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
+	}
+	km.KeyPairs = append(km.KeyPairs, kp)
+
+	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(config.Chain.ADA, serviceName), km)
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	respData := map[string]interface{}{
+		"service_name": serviceName,
+		"address":      address,
+		"public_key":   kp.PublicKey,
+		"address_type": addrType,
+		"network":      network,
+	}
+	backend.AddHDResponseData(respData, kp, generatedMnemonic)
+	return &logical.Response{Data: respData}, nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,114 @@
+package ada_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// мнемоника тестовых векторов CIP-19
+const testMnemonic = "test walk nut penalty hip pave soap entry language right filter choice"
+
+func TestAdaCreateAndListKeyManagers(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// Generate a key: без мнемоники только enterprise-адрес
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/svc")
+	req.Storage = storage
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^addr1v[02-9ac-hj-np-z]{52}$`, resp.Data["address"])
+	assert.Equal(t, "enterprise", resp.Data["address_type"])
+	assert.Len(t, resp.Data["public_key"], 64)
+
+	// Import a 32-byte seed
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+	}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	// RFC 8032 test 1: тот же публичный ключ, что у ed25519.NewKeyFromSeed
+	assert.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", resp.Data["public_key"])
+
+	// base без ключа стейкинга невозможен
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"address_type": "base"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+
+	// Read
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/ada/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 2)
+}
+
+func TestAdaCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// CIP-19 enterprise-адреса платёжного ключа m/1852'/1815'/0'/0/0
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/enterprise")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic":     testMnemonic,
+		"address_type": "enterprise",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8", resp.Data["address"])
+	assert.Equal(t, "m/1852'/1815'/0'/0/0", resp.Data["derivation_path"])
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/preprod")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic":     testMnemonic,
+		"address_type": "enterprise",
+		"network":      "preprod",
+	}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "addr_test1vz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzerspjrlsz", resp.Data["address"])
+
+	// HD сервис по умолчанию выдаёт base-адрес с тем же платёжным ключом
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/base")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"mnemonic": testMnemonic}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "base", resp.Data["address_type"])
+	assert.Regexp(t, `^addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer[02-9ac-hj-np-z]{52}$`, resp.Data["address"])
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/base")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/1852'/1815'/0'/0/1", resp.Data["derivation_path"])
+}
+
+func TestAdaCreateKeyManagers_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	for _, data := range []map[string]interface{}{
+		{"private_key": "zz"},
+		{"private_key": "0102"},
+		// 64 байта с kL, не кратным 8
+		{"private_key": "01000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+		{"network": "testnet"},
+		{"address_type": "pointer"},
+	} {
+		req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/bad")
+		req.Storage = storage
+		req.Data = data
+		_, err := b.HandleRequest(context.Background(), req)
+		assert.Error(t, err, data)
+	}
+}
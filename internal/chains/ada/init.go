@@ -0,0 +1,16 @@
+package ada
+
+import (
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+)
+
+func init() {
+	backend.Register(config.Chain.ADA, backend.Endpoints{
+		Crud:           PathCrud,
+		Sign:           PathSign,
+		Extra:          []func() *framework.Path{PathSignTx},
+		ValidateConfig: validateConfig,
+	})
+}
@@ -0,0 +1,53 @@
+package ada
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func PathSign() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSign(config.Chain.ADA),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signHash},
+		},
+		HelpSynopsis:    "Sign a 32‑byte hash",
+		HelpDescription: "POST name, address, hash(hex, e.g. Blake2b-256 transaction id) → signature(hex, 64-byte ed25519).",
+		Fields:          backend.DefaultSignOperation,
+	}
+}
+
+func signHash(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name, hashHex, address, err := backend.GetSignParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	kp, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.ADA)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing key for address %s: %w", address, err)
+	}
+
+	hash, err := hex.DecodeString(hashHex)
+	if err != nil || len(hash) != 32 {
+		return nil, fmt.Errorf("invalid hash: must be 32 bytes hex")
+	}
+
+	extended, err := hex.DecodeString(kp.PrivateKey)
+	if err != nil || len(extended) != 64 {
+		return nil, fmt.Errorf("stored private key is not a valid extended key")
+	}
+	defer zero(extended)
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(signExtended(extended, hash)),
+		},
+	}, nil
+}
@@ -0,0 +1,51 @@
+package ada_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaSignHash(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createAdaAccount(t, b, storage)
+
+	hash := make([]byte, 32)
+	hash[31] = 1
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/ada/svc/sign")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"hash":    hex.EncodeToString(hash),
+		"address": address,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	sig, err := hex.DecodeString(resp.Data["signature"].(string))
+	require.NoError(t, err)
+	require.Len(t, sig, 64)
+	assert.True(t, ed25519.Verify(pub, hash, sig))
+
+	req.Data["hash"] = "deadbeef"
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+// createAdaAccount создаёт HD сервис svc с base-адресом и возвращает адрес и публичный ключ
+func createAdaAccount(t *testing.T, b logical.Backend, storage logical.Storage) (string, ed25519.PublicKey) {
+	t.Helper()
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ada/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"mnemonic": testMnemonic}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pub, err := hex.DecodeString(resp.Data["public_key"].(string))
+	require.NoError(t, err)
+	return resp.Data["address"].(string), pub
+}
@@ -0,0 +1,233 @@
+package ada
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"golang.org/x/crypto/blake2b"
+)
+
+// ключи map transaction_body (CDDL Shelley и новее)
+const (
+	bodyKeyInputs  = 0
+	bodyKeyOutputs = 1
+	bodyKeyFee     = 2
+	bodyKeyTTL     = 3
+)
+
+// witnessKeyVKey — ключ vkeywitness в transaction_witness_set
+const witnessKeyVKey = 0
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"tx_body": {
+		Type:        framework.TypeString,
+		Description: "Hex CBOR transaction_body (map); a full transaction [body, witness_set, ...] is accepted too.",
+		Default:     "",
+	},
+}
+
+// txBody — поля transaction_body, которые возвращаются для проверки перед подписью
+type txBody struct {
+	Raw     []byte
+	Inputs  int
+	Outputs int
+	Fee     uint64
+	TTL     *uint64
+}
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.ADA),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signTx},
+		},
+		HelpSynopsis:    "Sign a Cardano transaction body.",
+		HelpDescription: "POST address, tx_body(hex CBOR) → tx_hash (Blake2b-256 of the body) and witness_set (hex CBOR with one vkey witness).",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+	input := strings.TrimPrefix(strings.TrimSpace(data.Get("tx_body").(string)), "0x")
+	if input == "" {
+		return nil, fmt.Errorf("tx_body is required")
+	}
+	raw, err := hex.DecodeString(input)
+	if err != nil {
+		return nil, fmt.Errorf("tx_body must be hex encoded")
+	}
+	body, err := decodeTxBody(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tx_body: %w", err)
+	}
+
+	kp, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.ADA)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing key for address %s: %w", address, err)
+	}
+	extended, err := hex.DecodeString(kp.PrivateKey)
+	if err != nil || len(extended) != 64 {
+		return nil, fmt.Errorf("stored private key is not a valid extended key")
+	}
+	defer zero(extended)
+
+	// id транзакции — Blake2b-256 исходных байт тела, без перекодирования
+	txHash := blake2b.Sum256(body.Raw)
+	vkey := hd.Ed25519ExtendedPublicKey(extended[:32])
+	sig := signExtended(extended, txHash[:])
+
+	witness := encodeVKeyWitness(vkey, sig)
+	// {0: [vkeywitness]}
+	witnessSet := cborAppendHead(nil, cborMap, 1)
+	witnessSet = cborAppendHead(witnessSet, cborUint, witnessKeyVKey)
+	witnessSet = cborAppendHead(witnessSet, cborArray, 1)
+	witnessSet = append(witnessSet, witness...)
+
+	respData := map[string]interface{}{
+		"address":      address,
+		"tx_hash":      hex.EncodeToString(txHash[:]),
+		"witness_set":  hex.EncodeToString(witnessSet),
+		"vkey_witness": hex.EncodeToString(witness),
+		"public_key":   hex.EncodeToString(vkey),
+		"signature":    hex.EncodeToString(sig),
+		"inputs":       body.Inputs,
+		"outputs":      body.Outputs,
+		"fee":          body.Fee,
+	}
+	if body.TTL != nil {
+		respData["ttl"] = *body.TTL
+	}
+	return &logical.Response{Data: respData}, nil
+}
+
+// encodeVKeyWitness — vkeywitness = [vkey, signature]
+func encodeVKeyWitness(vkey, sig []byte) []byte {
+	out := cborAppendHead(nil, cborArray, 2)
+	out = cborAppendBytes(out, vkey)
+	return cborAppendBytes(out, sig)
+}
+
+// decodeTxBody принимает transaction_body или целую транзакцию и возвращает тело с исходными байтами
+func decodeTxBody(raw []byte) (*txBody, error) {
+	size, err := cborItemLen(raw, 0)
+	if err != nil {
+		return nil, err
+	}
+	if size != len(raw) {
+		return nil, errors.New("trailing bytes after the CBOR item")
+	}
+
+	major, arg, n, _ := cborHead(raw)
+	if major == cborArray {
+		// transaction = [body, witness_set, is_valid, auxiliary_data] (Shelley — без is_valid)
+		if raw[0]&0x1f == cborIndefinite || (arg != 3 && arg != 4) {
+			return nil, errors.New("expected a transaction body map or a transaction array")
+		}
+		size, _ := cborItemLen(raw[n:], 1)
+		raw = raw[n : n+size]
+		major, arg, n, _ = cborHead(raw)
+	}
+	if major != cborMap || raw[0]&0x1f == cborIndefinite {
+		return nil, errors.New("transaction body must be a definite-length map")
+	}
+
+	body := &txBody{Raw: raw, Inputs: -1, Outputs: -1}
+	seen := make(map[uint64]bool, arg)
+	var hasFee bool
+	for i := uint64(0); i < arg; i++ {
+		keyMajor, key, kn, err := cborHead(raw[n:])
+		if err != nil {
+			return nil, err
+		}
+		if keyMajor != cborUint {
+			return nil, fmt.Errorf("transaction body keys must be unsigned integers")
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate transaction body key %d", key)
+		}
+		seen[key] = true
+		n += kn
+
+		value := raw[n:]
+		size, err := cborItemLen(value, 1)
+		if err != nil {
+			return nil, err
+		}
+		value = value[:size]
+		n += size
+
+		switch key {
+		case bodyKeyInputs, bodyKeyOutputs:
+			count, err := cborCount(value)
+			if err != nil {
+				return nil, fmt.Errorf("body key %d: %w", key, err)
+			}
+			if count < 0 {
+				count = indefiniteCount(value)
+			}
+			if key == bodyKeyInputs {
+				body.Inputs = count
+			} else {
+				body.Outputs = count
+			}
+		case bodyKeyFee, bodyKeyTTL:
+			valueMajor, v, _, _ := cborHead(value)
+			if valueMajor != cborUint {
+				return nil, fmt.Errorf("body key %d must be an unsigned integer", key)
+			}
+			if key == bodyKeyFee {
+				body.Fee, hasFee = v, true
+			} else {
+				body.TTL = &v
+			}
+		}
+	}
+
+	if body.Inputs < 1 {
+		return nil, errors.New("transaction body has no inputs")
+	}
+	if body.Outputs < 0 {
+		return nil, errors.New("transaction body has no outputs")
+	}
+	if !hasFee {
+		return nil, errors.New("transaction body has no fee")
+	}
+	return body, nil
+}
+
+// indefiniteCount считает элементы массива неопределённой длины (уже проверенного cborItemLen)
+func indefiniteCount(b []byte) int {
+	_, arg, n, _ := cborHead(b)
+	if b[0]>>5 == cborTag && arg == cborSetTag {
+		b = b[n:]
+		_, _, n, _ = cborHead(b)
+	}
+	count := 0
+	for b[n] != cborBreak {
+		size, _ := cborItemLen(b[n:], 1)
+		n += size
+		count++
+	}
+	return count
+}
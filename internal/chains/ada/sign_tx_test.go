@@ -0,0 +1,89 @@
+package ada_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// txBodyHex — {0: [[tx_id, 0]], 1: [[address, 1000000]], 2: 170000, 3: 5000}
+var txBodyHex = "a4" +
+	"0081825820" + strings.Repeat("11", 32) + "00" +
+	"018182581d61" + strings.Repeat("22", 28) + "1a000f4240" +
+	"021a00029810" +
+	"03191388"
+
+func TestAdaSignTx(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createAdaAccount(t, b, storage)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/ada/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address": address,
+		"tx_body": txBodyHex,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	body, _ := hex.DecodeString(txBodyHex)
+	txHash := blake2b.Sum256(body)
+	assert.Equal(t, hex.EncodeToString(txHash[:]), resp.Data["tx_hash"])
+	assert.Equal(t, uint64(170000), resp.Data["fee"])
+	assert.Equal(t, uint64(5000), resp.Data["ttl"])
+	assert.Equal(t, 1, resp.Data["inputs"])
+	assert.Equal(t, 1, resp.Data["outputs"])
+
+	sig, err := hex.DecodeString(resp.Data["signature"].(string))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(pub, txHash[:], sig))
+
+	// {0: [[vkey, signature]]}
+	expected := "a10081825820" + hex.EncodeToString(pub) + "5840" + hex.EncodeToString(sig)
+	assert.Equal(t, expected, resp.Data["witness_set"])
+
+	// целая транзакция [body, witness_set, is_valid, auxiliary_data] подписывается по телу
+	req.Data["tx_body"] = "84" + txBodyHex + "a0f5f6"
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(txHash[:]), resp.Data["tx_hash"])
+
+	// Conway: inputs как множество с тегом 258
+	req.Data["tx_body"] = "a4" + "00d90102" + txBodyHex[4:]
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Data["inputs"])
+}
+
+func TestAdaSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, _ := createAdaAccount(t, b, storage)
+
+	for _, body := range []string{
+		"",
+		"zz",
+		txBodyHex + "00",             // лишние байты
+		txBodyHex[:len(txBodyHex)-2], // обрезано
+		"a2" + txBodyHex[2:strings.Index(txBodyHex, "021a")], // нет fee
+		"a5" + txBodyHex[2:] + "021a00029810",                // повтор ключа
+		"82" + txBodyHex + "a0",                              // не транзакция
+		"a1" + "00" + strings.Repeat("81", 100) + "80",       // слишком глубокая вложенность
+	} {
+		req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/ada/svc/sign-tx")
+		req.Storage = storage
+		req.Data = map[string]interface{}{
+			"address": address,
+			"tx_body": body,
+		}
+		_, err := b.HandleRequest(context.Background(), req)
+		assert.Error(t, err, body)
+	}
+}
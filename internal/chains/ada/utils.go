@@ -0,0 +1,133 @@
+package ada
+
+import (
+	"crypto/sha512"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// CIP-1852 пути: платёжный ключ role 0 с индексом адреса, ключ стейкинга role 2 / 0 — общий для всех адресов сервиса
+const (
+	hdPathFormat = "m/1852'/1815'/0'/0/%d"
+	hdStakePath  = "m/1852'/1815'/0'/2/0"
+)
+
+// Типы Shelley-адресов (CIP-19); хранятся в KeyPair.AddressType
+const (
+	AddressTypeBase       = "base"       // платёжный ключ + ключ стейкинга, addr1q...
+	AddressTypeEnterprise = "enterprise" // только платёжный ключ, addr1v...
+)
+
+// заголовочный байт адреса: тип в старших 4 битах, network id в младших
+var addressHeaders = map[string]byte{
+	AddressTypeBase:       0x00,
+	AddressTypeEnterprise: 0x60,
+}
+
+// networkParams — network id и bech32 HRP адресов сети
+type networkParams struct {
+	ID  byte
+	HRP string
+}
+
+var networks = map[string]networkParams{
+	"mainnet": {ID: 1, HRP: "addr"},
+	"preprod": {ID: 0, HRP: "addr_test"},
+}
+
+func getNetworkParams(network string) (networkParams, error) {
+	if network == "" {
+		return networks[backend.NetworkMainnet], nil
+	}
+	params, ok := networks[network]
+	if !ok {
+		return networkParams{}, fmt.Errorf("unsupported network %q", network)
+	}
+	return params, nil
+}
+
+func parseAddressType(addrType string) (string, error) {
+	if _, ok := addressHeaders[addrType]; !ok {
+		return "", fmt.Errorf("unsupported address_type %q", addrType)
+	}
+	return addrType, nil
+}
+
+// validateConfig проверяет config/ada: допустимы network и address_type
+func validateConfig(cfg *types.ChainConfig) error {
+	if err := backend.CheckConfigFields(cfg, "network", "address_type"); err != nil {
+		return err
+	}
+	if _, err := getNetworkParams(cfg.Network); err != nil {
+		return err
+	}
+	if cfg.AddressType == "" {
+		return nil
+	}
+	_, err := parseAddressType(cfg.AddressType)
+	return err
+}
+
+// keyHash — Blake2b-224 публичного ключа, так ключ входит в адрес и в required_signers
+func keyHash(pub []byte) []byte {
+	h, _ := blake2b.New(28, nil)
+	h.Write(pub)
+	return h.Sum(nil)
+}
+
+// DeriveAddress builds a Shelley address: header || payment key hash [|| stake key hash], bech32 with the network HRP
+func DeriveAddress(addrType string, params networkParams, paymentPub, stakePub []byte) (string, error) {
+	header, ok := addressHeaders[addrType]
+	if !ok {
+		return "", fmt.Errorf("unsupported address_type %q", addrType)
+	}
+	payload := append([]byte{header | params.ID}, keyHash(paymentPub)...)
+	if addrType == AddressTypeBase {
+		payload = append(payload, keyHash(stakePub)...)
+	}
+	conv, err := bech32.ConvertBits(payload, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode(params.HRP, conv)
+}
+
+// extendedFromSeed превращает 32-байтовый ed25519 seed в расширенный ключ kL || kR (как внутри ed25519.Sign)
+func extendedFromSeed(seed []byte) []byte {
+	h := sha512.Sum512(seed)
+	h[0] &= 0xf8
+	h[31] &= 0x7f
+	h[31] |= 0x40
+	return h[:]
+}
+
+// signExtended — EdDSA подпись расширенным ключом: r = H(kR || M), S = r + H(R || A || M)·kL; проверяется обычным ed25519.Verify
+func signExtended(extended, msg []byte) []byte {
+	kL, kR := extended[:32], extended[32:64]
+	pub := hd.Ed25519ExtendedPublicKey(kL)
+
+	rh := sha512.New()
+	rh.Write(kR)
+	rh.Write(msg)
+	r, _ := edwards25519.NewScalar().SetUniformBytes(rh.Sum(nil))
+	R := new(edwards25519.Point).ScalarBaseMult(r).Bytes()
+
+	hh := sha512.New()
+	hh.Write(R)
+	hh.Write(pub)
+	hh.Write(msg)
+	h, _ := edwards25519.NewScalar().SetUniformBytes(hh.Sum(nil))
+
+	wide := make([]byte, 64)
+	copy(wide, kL)
+	a, _ := edwards25519.NewScalar().SetUniformBytes(wide)
+	s := edwards25519.NewScalar().MultiplyAdd(h, a, r)
+
+	return append(R, s.Bytes()...)
+}
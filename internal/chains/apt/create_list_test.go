@@ -0,0 +1,71 @@
+package apt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// мнемоника и адрес m/44'/637'/0'/0'/0' из тестов Aptos TS SDK
+const (
+	testMnemonic = "shoot island position soft burden budget tooth cruel issue economy destroy above"
+	testAddress  = "0x07968dab936c1bad187c60ce4082f307d030d780e91e694ae03aef16aba73f30"
+	testSeed     = "5d996aa76b3212142792d9130796cd2e11e3c445a93118c08414df4f66bc60ec"
+)
+
+func TestAptCreateAndListKeyManagers(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// Import a hex seed in the AIP-80 form
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/apt/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": "ed25519-priv-0x" + testSeed}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, testAddress, resp.Data["address"])
+
+	// Generate another key
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/apt/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^0x[0-9a-f]{64}$`, resp.Data["address"])
+
+	// Read
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/apt/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 2)
+
+	for _, bad := range []string{"zz", "0102", "ed25519-priv-0x01"} {
+		req = logical.TestRequest(t, logical.CreateOperation, "key-managers/apt/bad")
+		req.Storage = storage
+		req.Data = map[string]interface{}{"private_key": bad}
+		_, err = b.HandleRequest(context.Background(), req)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestAptCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/apt/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"mnemonic": testMnemonic}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, testAddress, resp.Data["address"])
+	assert.Equal(t, "m/44'/637'/0'/0'/0'", resp.Data["derivation_path"])
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/apt/hd")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/637'/1'/0'/0'", resp.Data["derivation_path"])
+}
@@ -0,0 +1,49 @@
+package apt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func PathSign() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSign(config.Chain.APT),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: sign},
+		},
+		HelpSynopsis:    "Sign an arbitrary hex message with an Aptos ed25519 key",
+		HelpDescription: "POST name, address, hash(hex message, signed as is) → signature(hex)",
+		Fields:          backend.DefaultSignOperation,
+	}
+}
+
+func sign(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name, hashInput, address, err := backend.GetSignParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+	msg, err := hex.DecodeString(strings.TrimPrefix(hashInput, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex message: %w", err)
+	}
+
+	priv, err := loadKey(ctx, req, name, address)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(priv)
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hex.EncodeToString(ed25519.Sign(priv, msg)),
+		},
+	}, nil
+}
@@ -0,0 +1,119 @@
+package apt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"golang.org/x/crypto/sha3"
+)
+
+// доменные префиксы: SHA3-256 от имени BCS-типа
+var (
+	rawTransactionSalt = sha3.Sum256([]byte("APTOS::RawTransaction"))
+	transactionSalt    = sha3.Sum256([]byte("APTOS::Transaction"))
+)
+
+// варианты BCS enum: TransactionAuthenticator::Ed25519 и Transaction::UserTransaction
+const (
+	authenticatorEd25519 = 0x00
+	userTransaction      = 0x00
+)
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"tx": {
+		Type:        framework.TypeString,
+		Description: "Hex BCS-serialized RawTransaction; its sender must be the address.",
+		Default:     "",
+	},
+}
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.APT),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signTx},
+		},
+		HelpSynopsis:    "Sign an Aptos RawTransaction.",
+		HelpDescription: "POST address, tx(hex BCS RawTransaction) → signature over SHA3-256(\"APTOS::RawTransaction\") || tx, the Ed25519 authenticator and the signed transaction (hex) with its hash.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+	input := strings.TrimPrefix(strings.TrimSpace(data.Get("tx").(string)), "0x")
+	if input == "" {
+		return nil, fmt.Errorf("tx is required")
+	}
+	raw, err := hex.DecodeString(input)
+	if err != nil {
+		return nil, fmt.Errorf("tx must be hex encoded")
+	}
+	tx, err := decodeRawTransaction(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tx: %w", err)
+	}
+
+	priv, err := loadKey(ctx, req, name, address)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(priv)
+	pub := priv.Public().(ed25519.PublicKey)
+
+	// подписывается только транзакция самого аккаунта
+	if sender := "0x" + hex.EncodeToString(tx.Sender); sender != address {
+		return nil, fmt.Errorf("transaction sender %s is not %s", sender, address)
+	}
+
+	signingMessage := append(append([]byte{}, rawTransactionSalt[:]...), raw...)
+	sig := ed25519.Sign(priv, signingMessage)
+
+	// TransactionAuthenticator::Ed25519 { public_key, signature }: длины — uleb128
+	authenticator := []byte{authenticatorEd25519, ed25519.PublicKeySize}
+	authenticator = append(authenticator, pub...)
+	authenticator = append(authenticator, ed25519.SignatureSize)
+	authenticator = append(authenticator, sig...)
+	signedTx := append(append([]byte{}, raw...), authenticator...)
+
+	hashInput := append(append([]byte{}, transactionSalt[:]...), userTransaction)
+	txHash := sha3.Sum256(append(hashInput, signedTx...))
+
+	respData := map[string]interface{}{
+		"address":                   address,
+		"public_key":                hex.EncodeToString(pub),
+		"signature":                 hex.EncodeToString(sig),
+		"authenticator":             hex.EncodeToString(authenticator),
+		"signed_tx":                 hex.EncodeToString(signedTx),
+		"tx_hash":                   "0x" + hex.EncodeToString(txHash[:]),
+		"sequence_number":           tx.SequenceNumber,
+		"payload_type":              tx.PayloadType,
+		"max_gas_amount":            tx.MaxGasAmount,
+		"gas_unit_price":            tx.GasUnitPrice,
+		"expiration_timestamp_secs": tx.Expiration,
+		"chain_id":                  tx.ChainID,
+	}
+	if tx.Function != "" {
+		respData["function"] = tx.Function
+	}
+	return &logical.Response{Data: respData}, nil
+}
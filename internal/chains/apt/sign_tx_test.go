@@ -0,0 +1,102 @@
+package apt_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+// transferTx собирает RawTransaction с 0x1::aptos_account::transfer(to, amount)
+func transferTx(sender string) []byte {
+	raw, _ := hex.DecodeString(strings.TrimPrefix(sender, "0x"))
+	raw = binary.LittleEndian.AppendUint64(raw, 7) // sequence_number
+
+	raw = append(raw, 0x02) // EntryFunction
+	module := make([]byte, 32)
+	module[31] = 0x01
+	raw = append(raw, module...)
+	raw = append(append(raw, 13), "aptos_account"...)
+	raw = append(append(raw, 8), "transfer"...)
+	raw = append(raw, 0x00) // ty_args
+	raw = append(raw, 0x02) // args
+	raw = append(append(raw, 32), make([]byte, 32)...)
+	raw = append(raw, 8)
+	raw = binary.LittleEndian.AppendUint64(raw, 1000)
+
+	raw = binary.LittleEndian.AppendUint64(raw, 2000)       // max_gas_amount
+	raw = binary.LittleEndian.AppendUint64(raw, 100)        // gas_unit_price
+	raw = binary.LittleEndian.AppendUint64(raw, 1700000000) // expiration_timestamp_secs
+	return append(raw, 1)                                   // chain_id
+}
+
+func TestAptSignTx(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createAptAccount(t, b, storage)
+	raw := transferTx(address)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/apt/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address": address,
+		"tx":      "0x" + hex.EncodeToString(raw),
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "0x1::aptos_account::transfer", resp.Data["function"])
+	assert.Equal(t, "entry_function", resp.Data["payload_type"])
+	assert.Equal(t, uint64(7), resp.Data["sequence_number"])
+	assert.Equal(t, uint64(2000), resp.Data["max_gas_amount"])
+	assert.Equal(t, uint64(100), resp.Data["gas_unit_price"])
+	assert.Equal(t, uint64(1700000000), resp.Data["expiration_timestamp_secs"])
+	assert.Equal(t, uint8(1), resp.Data["chain_id"])
+
+	// подпись над SHA3-256("APTOS::RawTransaction") || tx
+	salt := sha3.Sum256([]byte("APTOS::RawTransaction"))
+	sig, _ := hex.DecodeString(resp.Data["signature"].(string))
+	assert.True(t, ed25519.Verify(pub, append(salt[:], raw...), sig))
+
+	authenticator := "0020" + hex.EncodeToString(pub) + "40" + hex.EncodeToString(sig)
+	assert.Equal(t, authenticator, resp.Data["authenticator"])
+	assert.Equal(t, hex.EncodeToString(raw)+authenticator, resp.Data["signed_tx"])
+
+	txSalt := sha3.Sum256([]byte("APTOS::Transaction"))
+	signed, _ := hex.DecodeString(resp.Data["signed_tx"].(string))
+	txHash := sha3.Sum256(append(append(txSalt[:], 0x00), signed...))
+	assert.Equal(t, "0x"+hex.EncodeToString(txHash[:]), resp.Data["tx_hash"])
+}
+
+func TestAptSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, _ := createAptAccount(t, b, storage)
+	raw := transferTx(address)
+
+	foreign := transferTx("0x" + strings.Repeat("ab", 32))
+	unknownPayload := append([]byte{}, raw...)
+	unknownPayload[40] = 0x09
+
+	for _, tx := range []string{
+		"",
+		"zz",
+		hex.EncodeToString(raw[:60]),
+		hex.EncodeToString(foreign),
+		hex.EncodeToString(unknownPayload),
+	} {
+		req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/apt/svc/sign-tx")
+		req.Storage = storage
+		req.Data = map[string]interface{}{
+			"address": address,
+			"tx":      tx,
+		}
+		_, err := b.HandleRequest(context.Background(), req)
+		assert.Error(t, err, tx)
+	}
+}
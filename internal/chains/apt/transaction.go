@@ -0,0 +1,130 @@
+package apt
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RawTransaction: sender(32) || sequence_number(u64) || payload || max_gas_amount(u64) ||
+// gas_unit_price(u64) || expiration_timestamp_secs(u64) || chain_id(u8); хвост фиксированной длины
+const (
+	addressLen     = 32
+	rawTxHeaderLen = addressLen + 8
+	rawTxTailLen   = 8 + 8 + 8 + 1
+)
+
+// варианты TransactionPayload
+var payloadTypes = map[uint64]string{
+	0: "script",
+	1: "module_bundle",
+	2: "entry_function",
+	3: "multisig",
+}
+
+const payloadEntryFunction = 2
+
+// maxIdentifierLen ограничивает длину имени модуля или функции
+const maxIdentifierLen = 255
+
+// rawTransaction — поля RawTransaction, которые возвращаются для проверки перед подписью
+type rawTransaction struct {
+	Sender         []byte
+	SequenceNumber uint64
+	PayloadType    string
+	Function       string
+	MaxGasAmount   uint64
+	GasUnitPrice   uint64
+	Expiration     uint64
+	ChainID        uint8
+}
+
+// decodeRawTransaction разбирает заголовок и хвост RawTransaction; payload декодируется
+// только до имени entry function
+func decodeRawTransaction(raw []byte) (*rawTransaction, error) {
+	if len(raw) < rawTxHeaderLen+1+rawTxTailLen {
+		return nil, errors.New("transaction is too short")
+	}
+	tx := &rawTransaction{
+		Sender:         raw[:addressLen],
+		SequenceNumber: binary.LittleEndian.Uint64(raw[addressLen:rawTxHeaderLen]),
+	}
+	tail := raw[len(raw)-rawTxTailLen:]
+	tx.MaxGasAmount = binary.LittleEndian.Uint64(tail[0:8])
+	tx.GasUnitPrice = binary.LittleEndian.Uint64(tail[8:16])
+	tx.Expiration = binary.LittleEndian.Uint64(tail[16:24])
+	tx.ChainID = tail[24]
+
+	payload := raw[rawTxHeaderLen : len(raw)-rawTxTailLen]
+	variant, n, err := readULEB128(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	payloadType, ok := payloadTypes[variant]
+	if !ok {
+		return nil, fmt.Errorf("unsupported transaction payload variant %d", variant)
+	}
+	tx.PayloadType = payloadType
+	if variant == payloadEntryFunction {
+		tx.Function, err = decodeEntryFunctionName(payload[n:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry function: %w", err)
+		}
+	}
+	return tx, nil
+}
+
+// decodeEntryFunctionName читает ModuleId и имя функции: 0x1::aptos_account::transfer
+func decodeEntryFunctionName(b []byte) (string, error) {
+	if len(b) < addressLen {
+		return "", errors.New("truncated module address")
+	}
+	module := shortAddress(b[:addressLen])
+	b = b[addressLen:]
+	name, n, err := readIdentifier(b)
+	if err != nil {
+		return "", err
+	}
+	function, _, err := readIdentifier(b[n:])
+	if err != nil {
+		return "", err
+	}
+	return module + "::" + name + "::" + function, nil
+}
+
+func readIdentifier(b []byte) (string, int, error) {
+	size, n, err := readULEB128(b)
+	if err != nil {
+		return "", 0, err
+	}
+	if size == 0 || size > maxIdentifierLen || uint64(len(b)-n) < size {
+		return "", 0, errors.New("invalid identifier")
+	}
+	return string(b[n : n+int(size)]), n + int(size), nil
+}
+
+// readULEB128 читает длину или вариант enum в BCS (не длиннее u32)
+func readULEB128(b []byte) (uint64, int, error) {
+	var value uint64
+	for i := 0; i < 5; i++ {
+		if i >= len(b) {
+			return 0, 0, errors.New("truncated uleb128")
+		}
+		value |= uint64(b[i]&0x7f) << (7 * i)
+		if b[i]&0x80 == 0 {
+			return value, i + 1, nil
+		}
+	}
+	return 0, 0, errors.New("uleb128 overflows u32")
+}
+
+// shortAddress — адрес без ведущих нулей, как Aptos показывает 0x1
+func shortAddress(addr []byte) string {
+	s := strings.TrimLeft(hex.EncodeToString(addr), "0")
+	if s == "" {
+		s = "0"
+	}
+	return "0x" + s
+}
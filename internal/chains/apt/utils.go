@@ -0,0 +1,59 @@
+package apt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/logical"
+	"golang.org/x/crypto/sha3"
+)
+
+// hdPathFormat — SLIP-0010 путь Petra и Aptos SDK, %d заменяется индексом аккаунта
+const hdPathFormat = "m/44'/637'/%d'/0'/0'"
+
+// ed25519Scheme — байт схемы подписи в authentication key
+const ed25519Scheme = 0x00
+
+// privateKeyPrefix — AIP-80 префикс ed25519 приватного ключа
+const privateKeyPrefix = "ed25519-priv-"
+
+// DeriveAddress returns the account address of a fresh account: SHA3-256(public key || scheme), 0x-hex.
+func DeriveAddress(pub []byte) string {
+	authKey := sha3.Sum256(append(append([]byte{}, pub...), ed25519Scheme))
+	return "0x" + hex.EncodeToString(authKey[:])
+}
+
+// parsePrivateKey принимает 32-байтовый seed в hex, с 0x или AIP-80 префиксом
+func parsePrivateKey(input string) ([]byte, error) {
+	input = strings.TrimPrefix(input, privateKeyPrefix)
+	seed, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid private key: expected a 32-byte hex ed25519 seed")
+	}
+	return seed, nil
+}
+
+// loadKey достаёт ключ сервиса по адресу
+func loadKey(ctx context.Context, req *logical.Request, name, address string) (ed25519.PrivateKey, error) {
+	kp, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.APT)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing key for address %s: %w", address, err)
+	}
+	seed, err := hex.DecodeString(kp.PrivateKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("stored private key is not a valid seed")
+	}
+	defer zero(seed)
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -2,16 +2,13 @@ package btc
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
-	"fmt"
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
 	"github.com/dsshard/vault-crypto-adapters/internal/types"
 	"strings"
 
 	"github.com/btcsuite/btcd/btcec/v2"
-	"github.com/btcsuite/btcd/btcutil"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
 )
@@ -24,7 +21,7 @@ func PathCrud() *framework.Path {
 				Callback: createKeyManager,
 			},
 			logical.ReadOperation: &framework.PathOperation{
-				Callback: backend.WrapperReadKeyManager(config.Chain.BTC),
+				Callback: readKeyManager,
 			},
 			logical.DeleteOperation: &framework.PathOperation{
 				Callback: backend.WrapperDeleteKeyManager(config.Chain.BTC),
@@ -33,10 +30,47 @@ func PathCrud() *framework.Path {
 		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.BTC),
 		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
 		HelpDescription: backend.DefaultHelpDescriptionCreateList,
-		Fields:          backend.DefaultCrudOperations,
+		Fields:          createFields,
 	}
 }
 
+var createFields = backend.CrudFields(map[string]*framework.FieldSchema{
+	"network": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Network of the service: mainnet, testnet3, testnet4, signet or regtest. Defaults to config/btc, then mainnet. Fixed on the first key.",
+	},
+	"address_type": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Address type: legacy (P2PKH), nested-segwit (P2SH-P2WPKH), segwit (P2WPKH) or taproot (P2TR). Defaults to config/btc, then taproot",
+	},
+	"address_mode": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Taproot address mode: bip86 (default, BIP-341 tweaked output key) or untweaked",
+		Default:     AddressModeBip86,
+	},
+})
+
+// readKeyManager дополняет ответ сетью, типом и режимом адреса; старые записи — mainnet Taproot в режиме untweaked
+func readKeyManager(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	resp, err := backend.WrapperReadKeyManager(config.Chain.BTC)(ctx, req, data)
+	if err != nil || resp == nil || resp.Data == nil {
+		return resp, err
+	}
+	pairs, _ := resp.Data["key_pairs"].([]map[string]interface{})
+	if _, ok := resp.Data["network"]; !ok {
+		resp.Data["network"] = backend.NetworkMainnet
+	}
+	for _, pair := range pairs {
+		if _, ok := pair["address_type"]; !ok {
+			pair["address_type"] = AddressTypeTaproot
+		}
+		if _, ok := pair["address_mode"]; !ok && pair["address_type"] == AddressTypeTaproot {
+			pair["address_mode"] = AddressModeUntweaked
+		}
+	}
+	return resp, nil
+}
+
 func createKeyManager(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 	serviceName, ok := data.Get("name").(string)
 	if !ok {
@@ -44,61 +78,62 @@ func createKeyManager(ctx context.Context, req *logical.Request, data *framework
 	}
 	privateKey := strings.TrimSpace(data.Get("private_key").(string))
 
-	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.BTC, serviceName)
+	cfg, err := backend.GetChainConfig(ctx, req.Storage, config.Chain.BTC)
 	if err != nil {
 		return nil, err
 	}
-	if km == nil {
-		km = &types.KeyManager{ServiceName: serviceName}
+	requestedType := strings.TrimSpace(data.Get("address_type").(string))
+	if requestedType == "" {
+		requestedType = cfg.AddressType
 	}
-
-	// Attempt to decode or generate the private key
-	var privateKeyExport *btcec.PrivateKey
-	if privateKey != "" {
-		// 1) Попытка WIF
-		if wif, err := btcutil.DecodeWIF(privateKey); err == nil {
-			// WIF хранит уже сжатый ключ
-			privateKeyExport, _ = btcec.PrivKeyFromBytes(wif.PrivKey.Serialize())
-		} else if bts, err := hex.DecodeString(strings.TrimPrefix(privateKey, "0x")); err == nil && len(bts) == 32 {
-			// 2) Попытка raw‑hex
-			privateKeyExport, _ = btcec.PrivKeyFromBytes(bts)
-		}
-		// throw error
-		if privateKeyExport == nil {
-			return nil, fmt.Errorf("invalid private key")
-		}
+	addrType, err := parseAddressType(requestedType)
+	if err != nil {
+		return nil, err
 	}
-	if privateKeyExport == nil {
-		// 3) generate random
-		privateKeyExport, _ = btcec.NewPrivateKey()
+	mode, err := parseAddressMode(data.Get("address_mode").(string))
+	if err != nil {
+		return nil, err
+	}
+	if addrType != AddressTypeTaproot {
+		// режим твика относится только к Taproot
+		mode = ""
+	}
+	requestedNetwork := strings.TrimSpace(data.Get("network").(string))
+	if _, err := networkParams(requestedNetwork); err != nil {
+		return nil, err
 	}
 
-	// Serialize private key + public key
-	privateBytes := privateKeyExport.Serialize()
-	pubBytes := privateKeyExport.PubKey().SerializeCompressed()
+	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.BTC, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		km = &types.KeyManager{ServiceName: serviceName}
+	}
 
-	// Generate P2PKH‑address
-	address, err := DeriveAddress(privateKeyExport.PubKey())
+	network, err := backend.SetupNetwork(km, requestedNetwork, cfg.Network)
 	if err != nil {
 		return nil, err
 	}
-	kp := &types.KeyPair{
-		PrivateKey: hex.EncodeToString(privateBytes),
-		PublicKey:  hex.EncodeToString(pubBytes),
-		Address:    address,
+	params, err := networkParams(network)
+	if err != nil {
+		return nil, err
 	}
-	km.KeyPairs = append(km.KeyPairs, kp)
 
-	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(config.Chain.BTC, serviceName), km)
-	if err := req.Storage.Put(ctx, entry); err != nil {
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
 		return nil, err
 	}
 
-	return &logical.Response{
-		Data: map[string]interface{}{
-			"service_name": serviceName,
-			"address":      kp.Address,
-			"public_key":   kp.PublicKey,
+	return backend.CreateUTXOKeyPair(ctx, req, km, privateKey, generatedMnemonic, backend.UTXOKeySpec{
+		Chain:      config.Chain.BTC,
+		Network:    network,
+		Params:     params,
+		PathFormat: hdPathFormat(addrType, params),
+		Address: func(pub *btcec.PublicKey) (string, error) {
+			return DeriveAddress(pub, addrType, mode, params)
 		},
-	}, nil
+		AddressType: addrType,
+		AddressMode: mode,
+	})
 }
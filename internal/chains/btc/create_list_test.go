@@ -2,10 +2,15 @@ package btc_test
 
 import (
 	"context"
+	"encoding/hex"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 )
 
@@ -21,7 +26,8 @@ func TestBtcCreateAndListKeyManagers(t *testing.T) {
 	resp, err := b.HandleRequest(context.Background(), req)
 	require.NoError(t, err)
 	addr := resp.Data["address"].(string)
-	assert.Equal(t, "bc1qyr5sfdeg3570txvn7adftehdqz74fm7t8flp03k8d6xwhf8kkd9xd4y073", addr)
+	assert.Equal(t, "bc1paf7pjghy72n985s4ncnvcpsnpjkzeugm4z3sc8qmnt0l6v88upmsenwp6a", addr)
+	assert.Equal(t, "bip86", resp.Data["address_mode"])
 
 	// Generate another key
 	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
@@ -69,3 +75,258 @@ func TestBtcCreateAndListKeyManagers3(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid private key")
 }
+
+func TestBtcCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/86'/0'/0'/0/0", resp.Data["derivation_path"])
+	// BIP-86 test vector: internal key of m/86'/0'/0'/0/0
+	assert.True(t, strings.HasSuffix(resp.Data["public_key"].(string), "cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115"))
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", resp.Data["address"])
+}
+
+func TestBtcCreateKeyManagers_UntweakedMode(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key":  "KzQJ9vR4JeoJicejXmdvjcoDmZHa665diNxt17o3KRw3Hvix5CA5",
+		"address_mode": "untweaked",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "untweaked", resp.Data["address_mode"])
+	assert.True(t, strings.HasPrefix(resp.Data["address"].(string), "bc1p"))
+	// адрес, который для этого ключа выдавала старая версия, заново не воспроизводится
+	assert.NotEqual(t, baselineAddress, resp.Data["address"])
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"address_mode": "p2pkh"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+// запись в формате исходной версии: без address_type, address_mode и network, адрес — bc1q-кодировка
+// x-only ключа с байтом версии внутри данных (ключ — WIF KzQJ9vR4…)
+const (
+	baselinePrivateKey = "5ef536f7ffbbc6c8d701e45ab2fd4615c7810f594cd2a89cbe82c4b7efb95599"
+	baselinePublicKey  = "02074825b94469e7accc9fbad4af376805eaa77e59d3f0be363b74675d27b59a53"
+	baselineAddress    = "bc1qyr5sfdeg3570txvn7adftehdqz74fm7t8flp03k8d6xwhf8kkd9xd4y073"
+)
+
+func TestBtcSignHash_BaselineKeyManager(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	entry, err := logical.StorageEntryJSON("key-managers/btc/baseline", &types.KeyManager{
+		ServiceName: "baseline",
+		KeyPairs: []*types.KeyPair{{
+			PrivateKey: baselinePrivateKey,
+			PublicKey:  baselinePublicKey,
+			Address:    baselineAddress,
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, storage.Put(context.Background(), entry))
+
+	hash := make([]byte, 32)
+	hash[0] = 0x42
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/btc/baseline/sign")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"hash":    hex.EncodeToString(hash),
+		"address": baselineAddress,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	// подпись без твика: проверяется внутренним x-only ключом, как и в исходной версии
+	sig, err := schnorr.ParseSignature(mustDecodeHex(t, resp.Data["signature"].(string)))
+	require.NoError(t, err)
+	pub, err := btcec.ParsePubKey(mustDecodeHex(t, baselinePublicKey))
+	require.NoError(t, err)
+	assert.True(t, sig.Verify(hash, pub))
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestBtcReadKeyManagers_LegacyMode(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	// запись, созданная до появления address_type и address_mode
+	entry, err := logical.StorageEntryJSON("key-managers/btc/legacy", &types.KeyManager{
+		ServiceName: "legacy",
+		KeyPairs: []*types.KeyPair{{
+			PrivateKey: baselinePrivateKey,
+			PublicKey:  baselinePublicKey,
+			Address:    baselineAddress,
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, storage.Put(context.Background(), entry))
+
+	req := logical.TestRequest(t, logical.ReadOperation, "key-managers/btc/legacy")
+	req.Storage = storage
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, pairs, 1)
+	assert.Equal(t, baselineAddress, pairs[0]["address"])
+	assert.Equal(t, baselinePublicKey, pairs[0]["public_key"])
+	assert.Equal(t, "taproot", pairs[0]["address_type"])
+	assert.Equal(t, "untweaked", pairs[0]["address_mode"])
+}
+
+func TestBtcCreateKeyManagers_AddressTypes(t *testing.T) {
+	// BIP-44/49/84/86 test vectors for m/<purpose>'/0'/0'/0/0 of the "abandon … about" mnemonic
+	cases := map[string]struct {
+		path    string
+		address string
+	}{
+		"legacy":        {"m/44'/0'/0'/0/0", "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		"nested-segwit": {"m/49'/0'/0'/0/0", "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		"segwit":        {"m/84'/0'/0'/0/0", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		"taproot":       {"m/86'/0'/0'/0/0", "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+	}
+	for addrType, tc := range cases {
+		t.Run(addrType, func(t *testing.T) {
+			b, storage := test.NewTestBackend(t)
+			req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/hd")
+			req.Storage = storage
+			req.Data = map[string]interface{}{
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"address_type": addrType,
+			}
+			resp, err := b.HandleRequest(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.path, resp.Data["derivation_path"])
+			assert.Equal(t, tc.address, resp.Data["address"])
+			assert.Equal(t, addrType, resp.Data["address_type"])
+		})
+	}
+
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"address_type": "p2wsh"}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+func TestBtcCreateKeyManagers_MixedAddressTypesIndexes(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	create := func(data map[string]interface{}) *logical.Response {
+		req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/hd")
+		req.Storage = storage
+		req.Data = data
+		resp, err := b.HandleRequest(context.Background(), req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	create(map[string]interface{}{
+		"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"address_type": "segwit",
+	})
+	// у каждого purpose своя последовательность индексов: taproot начинается с 0
+	resp := create(map[string]interface{}{"address_type": "taproot"})
+	assert.Equal(t, "m/86'/0'/0'/0/0", resp.Data["derivation_path"])
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", resp.Data["address"])
+
+	// BIP-84 test vector m/84'/0'/0'/0/1
+	resp = create(map[string]interface{}{"address_type": "segwit"})
+	assert.Equal(t, "m/84'/0'/0'/0/1", resp.Data["derivation_path"])
+	assert.Equal(t, "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g", resp.Data["address"])
+}
+
+func TestBtcCreateKeyManagers_Network(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/staging")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"address_type": "segwit",
+		"network":      "testnet3",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	// BIP-84 test vector, coin type 1' for test networks
+	assert.Equal(t, "m/84'/1'/0'/0/0", resp.Data["derivation_path"])
+	assert.Equal(t, "tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl", resp.Data["address"])
+	assert.Equal(t, "testnet3", resp.Data["network"])
+
+	// the network of an existing service cannot change
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/staging")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"network": "mainnet"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/btc/staging")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "testnet3", resp.Data["network"])
+
+	// regtest uses its own HRP
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/local")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"network": "regtest"}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.Data["address"].(string), "bcrt1p"))
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/other")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"network": "testnet9"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+func TestBtcCreateKeyManagers_WIFNetwork(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	// mainnet WIF into a signet service
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/signet")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "KzQJ9vR4JeoJicejXmdvjcoDmZHa665diNxt17o3KRw3Hvix5CA5",
+		"network":     "signet",
+	}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not belong to network signet")
+}
+
+func TestBtcCreateKeyManagers_MountConfig(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.UpdateOperation, "config/btc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"network": "testnet4", "address_type": "segwit"}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "testnet4", resp.Data["network"])
+	assert.Equal(t, "segwit", resp.Data["address_type"])
+	assert.True(t, strings.HasPrefix(resp.Data["address"].(string), "tb1q"))
+
+	// request fields take precedence over the mount defaults
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"address_type": "taproot"}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.Data["address"].(string), "tb1p"))
+}
@@ -3,11 +3,14 @@ package btc
 import (
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
 )
 
 func init() {
 	backend.Register(config.Chain.BTC, backend.Endpoints{
-		Crud: PathCrud,
-		Sign: PathSign,
+		Crud:           PathCrud,
+		Sign:           PathSign,
+		Extra:          []func() *framework.Path{PathSignPsbt},
+		ValidateConfig: validateConfig,
 	})
 }
@@ -4,23 +4,34 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
+var signFields = backend.SignFields(map[string]*framework.FieldSchema{
+	"sighash_type": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) Sighash byte appended to the signature. Defaults to SIGHASH_ALL (1) for ECDSA and SIGHASH_DEFAULT (0, no byte) for taproot.",
+		Default:     -1,
+	},
+})
+
 func PathSign() *framework.Path {
 	return &framework.Path{
 		Pattern: config.CreatePathSign(config.Chain.BTC),
 		Operations: map[logical.Operation]framework.OperationHandler{
 			logical.UpdateOperation: &framework.PathOperation{Callback: signHash},
 		},
-		HelpSynopsis:    "Sign a 32‑byte hash with secp256k1 (schnorr)",
-		HelpDescription: "POST name, hash(hex) → signature(hex).",
-		Fields:          backend.DefaultSignOperation,
+		HelpSynopsis:    "Sign a 32‑byte sighash with the key of the address",
+		HelpDescription: "POST name, address, hash(hex) [+ sighash_type] → signature(hex): DER ECDSA + sighash byte for legacy/segwit addresses, schnorr for taproot.",
+		Fields:          signFields,
 	}
 }
 
@@ -39,13 +50,55 @@ func signHash(ctx context.Context, req *logical.Request, data *framework.FieldDa
 	if err != nil || len(hash) != 32 {
 		return nil, fmt.Errorf("invalid hash")
 	}
-	privBytes, _ := hex.DecodeString(keyManager.PrivateKey)
-	priv, _ := btcec.PrivKeyFromBytes(privBytes)
-	sig, err := schnorr.Sign(priv, hash)
+	addrType := addressType(keyManager.AddressType)
+	hashType, err := parseSigHashType(data.Get("sighash_type").(int), addrType)
 	if err != nil {
 		return nil, err
 	}
+
+	privBytes, _ := hex.DecodeString(keyManager.PrivateKey)
+	priv, _ := btcec.PrivKeyFromBytes(privBytes)
+	defer priv.Zero()
+
+	var sig []byte
+	if addrType == AddressTypeTaproot {
+		// подпись должна проверяться ключом из адреса: для BIP-86 — твикнутым выходным ключом
+		key := signingKey(priv, keyManager.AddressMode)
+		s, err := schnorr.Sign(key, hash)
+		if err != nil {
+			return nil, err
+		}
+		sig = s.Serialize()
+		if hashType != txscript.SigHashDefault {
+			sig = append(sig, byte(hashType))
+		}
+	} else {
+		// DER (low-S) + sighash byte — формат элемента scriptSig/witness
+		sig = append(ecdsa.Sign(priv, hash).Serialize(), byte(hashType))
+	}
+
 	return &logical.Response{Data: map[string]interface{}{
-		"signature": hex.EncodeToString(sig.Serialize()),
+		"signature":    hex.EncodeToString(sig),
+		"address_type": addrType,
 	}}, nil
 }
+
+// parseSigHashType проверяет sighash; -1 означает значение по умолчанию для типа адреса
+func parseSigHashType(value int, addrType string) (txscript.SigHashType, error) {
+	if value == -1 {
+		if addrType == AddressTypeTaproot {
+			return txscript.SigHashDefault, nil
+		}
+		return txscript.SigHashAll, nil
+	}
+	hashType := txscript.SigHashType(value)
+	switch hashType &^ txscript.SigHashAnyOneCanPay {
+	case txscript.SigHashAll, txscript.SigHashNone, txscript.SigHashSingle:
+		return hashType, nil
+	case txscript.SigHashDefault:
+		if addrType == AddressTypeTaproot && hashType == txscript.SigHashDefault {
+			return hashType, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported sighash_type %d", value)
+}
@@ -0,0 +1,204 @@
+package btc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+var signPsbtFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"psbt": {
+		Type:        framework.TypeString,
+		Description: "Base64-encoded PSBT (BIP-174).",
+		Default:     "",
+	},
+	"finalize": {
+		Type:        framework.TypeBool,
+		Description: "(Optional) Finalize the PSBT and return the extracted raw transaction.",
+		Default:     false,
+	},
+}
+
+// taprootSigner — ключ сервиса, которым подписывается вход с данным witness-скриптом
+type taprootSigner struct {
+	privateKey string
+	tweak      bool
+}
+
+func PathSignPsbt() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignPsbt(config.Chain.BTC),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signPsbt},
+		},
+		HelpSynopsis:    "Sign Taproot key-path inputs of a PSBT.",
+		HelpDescription: "POST psbt(base64) [+ finalize] → psbt(base64) with tap_key_sig for every input owned by the service, optionally the raw transaction.",
+		Fields:          signPsbtFields,
+	}
+}
+
+func signPsbt(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name, ok := data.Get("name").(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid request data: missing or invalid 'name' field")
+	}
+	encoded := strings.TrimSpace(data.Get("psbt").(string))
+	if encoded == "" {
+		return nil, fmt.Errorf("invalid request data: missing 'psbt' field")
+	}
+
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(encoded), true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid psbt: %w", err)
+	}
+
+	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.BTC, name)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		return nil, fmt.Errorf("key-manager %s does not exist", name)
+	}
+
+	signers, err := taprootSigners(km.KeyPairs)
+	if err != nil {
+		return nil, err
+	}
+
+	// BIP-341 sighash коммитит все prevout'ы, поэтому нужны witness_utxo каждого входа
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(packet.Inputs))
+	for i, in := range packet.Inputs {
+		if in.WitnessUtxo == nil {
+			return nil, fmt.Errorf("input %d is missing witness_utxo", i)
+		}
+		prevOuts[packet.UnsignedTx.TxIn[i].PreviousOutPoint] = in.WitnessUtxo
+	}
+	fetcher := txscript.NewMultiPrevOutFetcher(prevOuts)
+	sigHashes := txscript.NewTxSigHashes(packet.UnsignedTx, fetcher)
+
+	signed := make([]int, 0, len(packet.Inputs))
+	for i := range packet.Inputs {
+		in := &packet.Inputs[i]
+		signer, ok := signers[hex.EncodeToString(in.WitnessUtxo.PkScript)]
+		if !ok || len(in.FinalScriptWitness) > 0 {
+			continue
+		}
+
+		// нулевой sighash type в PSBT означает SIGHASH_DEFAULT
+		hashType := in.SighashType
+		sigHash, err := txscript.CalcTaprootSignatureHash(sigHashes, hashType, packet.UnsignedTx, i, fetcher)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute sighash for input %d: %w", i, err)
+		}
+
+		sig, internalKey, err := signTaproot(signer, sigHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign input %d: %w", i, err)
+		}
+		if hashType != txscript.SigHashDefault {
+			sig = append(sig, byte(hashType))
+		}
+		in.TaprootKeySpendSig = sig
+		if signer.tweak && len(in.TaprootInternalKey) == 0 {
+			in.TaprootInternalKey = internalKey
+		}
+		signed = append(signed, i)
+	}
+
+	if len(signed) == 0 {
+		return nil, errors.New("no inputs belong to the key-manager")
+	}
+
+	respData := map[string]interface{}{
+		"signed_inputs": signed,
+	}
+
+	if data.Get("finalize").(bool) {
+		if err := psbt.MaybeFinalizeAll(packet); err != nil {
+			return nil, fmt.Errorf("failed to finalize psbt: %w", err)
+		}
+		tx, err := psbt.Extract(packet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract transaction: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := tx.Serialize(&buf); err != nil {
+			return nil, err
+		}
+		respData["tx"] = hex.EncodeToString(buf.Bytes())
+		respData["txid"] = tx.TxHash().String()
+	}
+
+	out, err := packet.B64Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode psbt: %w", err)
+	}
+	respData["psbt"] = out
+
+	return &logical.Response{Data: respData}, nil
+}
+
+// taprootSigners сопоставляет P2TR-скрипты с ключами сервиса согласно режиму адреса каждой пары
+func taprootSigners(pairs []*types.KeyPair) (map[string]taprootSigner, error) {
+	signers := make(map[string]taprootSigner, len(pairs))
+	for _, kp := range pairs {
+		if addressType(kp.AddressType) != AddressTypeTaproot {
+			continue
+		}
+		pubBytes, err := hex.DecodeString(kp.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("stored public key is not valid hex: %w", err)
+		}
+		pub, err := btcec.ParsePubKey(pubBytes)
+		if err != nil {
+			return nil, fmt.Errorf("stored public key is invalid: %w", err)
+		}
+
+		script, err := txscript.PayToTaprootScript(outputKey(pub, kp.AddressMode))
+		if err != nil {
+			return nil, err
+		}
+		signers[hex.EncodeToString(script)] = taprootSigner{
+			privateKey: kp.PrivateKey,
+			tweak:      addressMode(kp.AddressMode) == AddressModeBip86,
+		}
+	}
+	return signers, nil
+}
+
+// signTaproot подписывает sighash; для BIP-86 выхода ключ предварительно твикается (BIP-341 TapTweak)
+func signTaproot(signer taprootSigner, sigHash []byte) (sig []byte, internalKey []byte, err error) {
+	privBytes, err := hex.DecodeString(signer.privateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("stored private key is not valid hex: %w", err)
+	}
+	priv, pub := btcec.PrivKeyFromBytes(privBytes)
+	defer priv.Zero()
+
+	signingKey := priv
+	if signer.tweak {
+		signingKey = txscript.TweakTaprootPrivKey(*priv, nil)
+		defer signingKey.Zero()
+	}
+
+	s, err := schnorr.Sign(signingKey, sigHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.Serialize(), schnorr.SerializePubKey(pub), nil
+}
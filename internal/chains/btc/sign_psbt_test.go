@@ -0,0 +1,141 @@
+package btc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTaprootPsbt spends two fake outputs: one locked to pkScript, one foreign
+func buildTaprootPsbt(t *testing.T, pkScript []byte) (*psbt.Packet, []*wire.TxOut) {
+	t.Helper()
+	foreign, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	foreignScript, err := txscript.PayToTaprootScript(txscript.ComputeTaprootKeyNoScript(foreign.PubKey()))
+	require.NoError(t, err)
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}, nil, nil))
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{2}, Index: 1}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(90_000, foreignScript))
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(t, err)
+	prevOuts := []*wire.TxOut{wire.NewTxOut(50_000, pkScript), wire.NewTxOut(50_000, foreignScript)}
+	packet.Inputs[0].WitnessUtxo = prevOuts[0]
+	packet.Inputs[1].WitnessUtxo = prevOuts[1]
+	return packet, prevOuts
+}
+
+func TestBtcSignPsbt_Bip86(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// BIP-86 test vector: m/86'/0'/0'/0/0 of the "abandon … about" mnemonic
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	account, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	pubBytes, _ := hex.DecodeString(account.Data["public_key"].(string))
+	pub, err := btcec.ParsePubKey(pubBytes)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToTaprootScript(txscript.ComputeTaprootKeyNoScript(pub))
+	require.NoError(t, err)
+	require.Equal(t, "5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", hex.EncodeToString(pkScript))
+
+	packet, prevOuts := buildTaprootPsbt(t, pkScript)
+	encoded, err := packet.B64Encode()
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/btc/svc/sign-psbt")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"psbt": encoded}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, resp.Data["signed_inputs"])
+
+	signedPacket, err := psbt.NewFromRawBytes(bytes.NewReader([]byte(resp.Data["psbt"].(string))), true)
+	require.NoError(t, err)
+	assert.Len(t, signedPacket.Inputs[0].TaprootKeySpendSig, 64)
+	assert.Equal(t, pubBytes[1:], signedPacket.Inputs[0].TaprootInternalKey)
+	assert.Nil(t, signedPacket.Inputs[1].TaprootKeySpendSig)
+
+	// the signature must satisfy the script interpreter for input 0
+	tx := signedPacket.UnsignedTx.Copy()
+	tx.TxIn[0].Witness = wire.TxWitness{signedPacket.Inputs[0].TaprootKeySpendSig}
+	fetcher := txscript.NewMultiPrevOutFetcher(map[wire.OutPoint]*wire.TxOut{
+		tx.TxIn[0].PreviousOutPoint: prevOuts[0],
+		tx.TxIn[1].PreviousOutPoint: prevOuts[1],
+	})
+	vm, err := txscript.NewEngine(prevOuts[0].PkScript, tx, 0, txscript.StandardVerifyFlags,
+		nil, txscript.NewTxSigHashes(tx, fetcher), prevOuts[0].Value, fetcher)
+	require.NoError(t, err)
+	require.NoError(t, vm.Execute())
+}
+
+func TestBtcSignPsbt_Finalize(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	account, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	pubBytes, _ := hex.DecodeString(account.Data["public_key"].(string))
+	pub, err := btcec.ParsePubKey(pubBytes)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToTaprootScript(txscript.ComputeTaprootKeyNoScript(pub))
+	require.NoError(t, err)
+
+	// both inputs belong to the service → the PSBT can be finalized
+	packet, _ := buildTaprootPsbt(t, pkScript)
+	packet.Inputs[1].WitnessUtxo = wire.NewTxOut(50_000, pkScript)
+	encoded, err := packet.B64Encode()
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/btc/svc/sign-psbt")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"psbt": encoded, "finalize": true}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, resp.Data["signed_inputs"])
+
+	raw, err := hex.DecodeString(resp.Data["tx"].(string))
+	require.NoError(t, err)
+	var tx wire.MsgTx
+	require.NoError(t, tx.Deserialize(bytes.NewReader(raw)))
+	assert.Len(t, tx.TxIn[0].Witness, 1)
+	assert.Equal(t, tx.TxHash().String(), resp.Data["txid"])
+}
+
+func TestBtcSignPsbt_NoOwnInputs(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	packet, _ := buildTaprootPsbt(t, []byte{txscript.OP_1, txscript.OP_DATA_32, 0x01})
+	encoded, err := packet.B64Encode()
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/btc/svc/sign-psbt")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"psbt": encoded}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no inputs belong")
+}
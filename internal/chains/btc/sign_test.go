@@ -3,11 +3,15 @@ package btc_test
 import (
 	"context"
 	"encoding/hex"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/dsshard/vault-crypto-adapters/internal/test"
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 )
 
@@ -31,4 +35,50 @@ func TestBtcSignHash(t *testing.T) {
 	require.NoError(t, err)
 	sig, _ := hex.DecodeString(resp.Data["signature"].(string))
 	assert.Len(t, sig, schnorr.SignatureSize)
+
+	// BIP-86: подпись проверяется x-only ключом из адреса (твикнутым)
+	pubBytes, _ := hex.DecodeString(account.Data["public_key"].(string))
+	pub, err := btcec.ParsePubKey(pubBytes)
+	require.NoError(t, err)
+	parsed, err := schnorr.ParseSignature(sig)
+	require.NoError(t, err)
+	assert.True(t, parsed.Verify(make([]byte, 32), txscript.ComputeTaprootKeyNoScript(pub)))
+	assert.False(t, parsed.Verify(make([]byte, 32), pub))
+}
+
+func TestBtcSignHash_Segwit(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/btc/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"address_type": "segwit"}
+	account, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(account.Data["address"].(string), "bc1q"))
+
+	hash := make([]byte, 32)
+	hash[31] = 1
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/btc/svc/sign")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"hash":    hex.EncodeToString(hash),
+		"address": account.Data["address"],
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "segwit", resp.Data["address_type"])
+
+	// DER + SIGHASH_ALL
+	sig, _ := hex.DecodeString(resp.Data["signature"].(string))
+	assert.Equal(t, byte(txscript.SigHashAll), sig[len(sig)-1])
+	parsed, err := ecdsa.ParseDERSignature(sig[:len(sig)-1])
+	require.NoError(t, err)
+	pubBytes, _ := hex.DecodeString(account.Data["public_key"].(string))
+	pub, err := btcec.ParsePubKey(pubBytes)
+	require.NoError(t, err)
+	assert.True(t, parsed.Verify(hash, pub))
+
+	// SIGHASH_DEFAULT допустим только для taproot
+	req.Data["sighash_type"] = 0
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
 }
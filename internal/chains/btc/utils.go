@@ -1,29 +1,165 @@
 package btc
 
 import (
+	"fmt"
+
 	"github.com/btcsuite/btcd/btcec/v2"
-	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
 )
 
-// DeriveAddress BtcDeriveAddress builds a native‐SegWit v1 (Taproot) address (bc1p...)
-func DeriveAddress(pub *btcec.PublicKey) (string, error) {
-	// 1) Get the 33‑byte compressed pubkey, drop the 0x02/0x03 prefix → 32 bytes x-only
-	comp := pub.SerializeCompressed() // [0x02/0x03 || X(32)]
-	xOnly := comp[1:]                 // 32 bytes
+// Типы адресов, которые выводятся из одного secp256k1 ключа; хранятся в KeyPair.AddressType
+const (
+	AddressTypeLegacy       = "legacy"        // P2PKH, 1...
+	AddressTypeNestedSegwit = "nested-segwit" // P2SH-P2WPKH, 3...
+	AddressTypeSegwit       = "segwit"        // P2WPKH, bc1q...
+	AddressTypeTaproot      = "taproot"       // P2TR, bc1p...
+)
 
-	// 2) Build the witness program: version=1 || data=xOnly
-	program := append([]byte{0x01}, xOnly...)
+// hdPurposes — BIP-44/49/84/86 purpose по типу адреса
+var hdPurposes = map[string]uint32{
+	AddressTypeLegacy:       44,
+	AddressTypeNestedSegwit: 49,
+	AddressTypeSegwit:       84,
+	AddressTypeTaproot:      86,
+}
 
-	// 3) Convert from 8‑bit to 5‑bit groups (per BIP‑173/BIP‑350)
-	data5, err := bech32.ConvertBits(program, 8, 5, true)
-	if err != nil {
-		return "", err
+// testNet4Params — BIP-94 testnet4; кодирование адресов и WIF совпадает с testnet3
+var testNet4Params = func() chaincfg.Params {
+	params := chaincfg.TestNet3Params
+	params.Name = "testnet4"
+	params.Net = 0x283f161c
+	params.DefaultPort = "48333"
+	return params
+}()
+
+// networks — параметры сетей по значению настройки network
+var networks = map[string]*chaincfg.Params{
+	"mainnet":  &chaincfg.MainNetParams,
+	"testnet3": &chaincfg.TestNet3Params,
+	"testnet4": &testNet4Params,
+	"signet":   &chaincfg.SigNetParams,
+	"regtest":  &chaincfg.RegressionNetParams,
+}
+
+// networkParams возвращает параметры сети; пустое значение — mainnet
+func networkParams(network string) (*chaincfg.Params, error) {
+	return backend.LookupNetwork(networks, network)
+}
+
+// validateConfig проверяет config/btc: допустимы network и address_type
+func validateConfig(cfg *types.ChainConfig) error {
+	if err := backend.CheckConfigFields(cfg, "network", "address_type"); err != nil {
+		return err
 	}
+	if _, err := networkParams(cfg.Network); err != nil {
+		return err
+	}
+	_, err := parseAddressType(cfg.AddressType)
+	return err
+}
+
+// hdPathFormat возвращает BIP-44 совместимый путь для типа адреса и сети (coin type 0' или 1' для тестовых сетей),
+// %d заменяется индексом адреса
+func hdPathFormat(addrType string, params *chaincfg.Params) string {
+	return fmt.Sprintf("m/%d'/%d'/0'/0/%%d", hdPurposes[addrType], params.HDCoinType)
+}
+
+// Режимы Taproot-адреса, хранятся в KeyPair.AddressMode
+const (
+	// AddressModeBip86 — выходной ключ твикается по BIP-341 без скриптового дерева (как в BIP-86 кошельках)
+	AddressModeBip86 = "bip86"
+	// AddressModeUntweaked — witness program равен x-only внутреннему ключу без твика (валидный bc1p…).
+	// Старые адреса были некорректной bc1q-кодировкой с байтом версии внутри данных; такие записи
+	// подписывают этим же ключом по сохранённому адресу, но заново в этом режиме получится другой адрес
+	AddressModeUntweaked = "untweaked"
+)
+
+// addressType возвращает тип пары; пустое значение — записи, созданные до появления типов (только Taproot)
+func addressType(addrType string) string {
+	if addrType == "" {
+		return AddressTypeTaproot
+	}
+	return addrType
+}
 
-	// 4) bech32m‐Encode with HRP "bc" (for mainnet). Use "tb" for testnet.
-	addr, err := bech32.EncodeM("bc", data5)
+func parseAddressType(addrType string) (string, error) {
+	if addrType == "" {
+		return AddressTypeTaproot, nil
+	}
+	if _, ok := hdPurposes[addrType]; !ok {
+		return "", fmt.Errorf("unsupported address_type %q", addrType)
+	}
+	return addrType, nil
+}
+
+// addressMode возвращает режим пары; пустое значение — записи, созданные до появления режимов
+func addressMode(mode string) string {
+	if mode == "" {
+		return AddressModeUntweaked
+	}
+	return mode
+}
+
+func parseAddressMode(mode string) (string, error) {
+	switch mode {
+	case "", AddressModeBip86:
+		return AddressModeBip86, nil
+	case AddressModeUntweaked:
+		return AddressModeUntweaked, nil
+	}
+	return "", fmt.Errorf("unsupported address_mode %q", mode)
+}
+
+// outputKey возвращает ключ, который попадает в witness program для данного режима
+func outputKey(pub *btcec.PublicKey, mode string) *btcec.PublicKey {
+	if addressMode(mode) == AddressModeBip86 {
+		return txscript.ComputeTaprootKeyNoScript(pub)
+	}
+	return pub
+}
+
+// signingKey возвращает секрет, соответствующий outputKey: для BIP-86 — твикнутый ключ
+func signingKey(priv *btcec.PrivateKey, mode string) *btcec.PrivateKey {
+	if addressMode(mode) == AddressModeBip86 {
+		return txscript.TweakTaprootPrivKey(*priv, nil)
+	}
+	return priv
+}
+
+// DeriveAddress BtcDeriveAddress builds the address of the given type for the network params;
+// mode only affects taproot addresses.
+func DeriveAddress(pub *btcec.PublicKey, addrType, mode string, params *chaincfg.Params) (string, error) {
+	pubKeyHash := btcutil.Hash160(pub.SerializeCompressed())
+
+	var (
+		addr btcutil.Address
+		err  error
+	)
+	switch addressType(addrType) {
+	case AddressTypeLegacy:
+		addr, err = btcutil.NewAddressPubKeyHash(pubKeyHash, params)
+	case AddressTypeNestedSegwit:
+		// redeem script: OP_0 <20-byte pubkey hash>
+		redeemScript, scriptErr := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(pubKeyHash).Script()
+		if scriptErr != nil {
+			return "", scriptErr
+		}
+		addr, err = btcutil.NewAddressScriptHash(redeemScript, params)
+	case AddressTypeSegwit:
+		addr, err = btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, params)
+	case AddressTypeTaproot:
+		// 32‑byte x-only output key (tweaked in BIP-86 mode), bech32m witness v1
+		addr, err = btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey(pub, mode)), params)
+	default:
+		return "", fmt.Errorf("unsupported address_type %q", addrType)
+	}
 	if err != nil {
 		return "", err
 	}
-	return addr, nil
+	return addr.EncodeAddress(), nil
 }
@@ -0,0 +1,140 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// PathCrud registers CRUD operations for Cosmos SDK key-managers
+func PathCrud() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathCrud(config.Chain.COSMOS),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.CreateOperation: &framework.PathOperation{
+				Callback: createKeyManager,
+			},
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: backend.WrapperReadKeyManager(config.Chain.COSMOS),
+			},
+			logical.DeleteOperation: &framework.PathOperation{
+				Callback: backend.WrapperDeleteKeyManager(config.Chain.COSMOS),
+			},
+		},
+		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.COSMOS),
+		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
+		HelpDescription: backend.DefaultHelpDescriptionCreateList,
+		Fields:          createFields,
+	}
+}
+
+var createFields = backend.CrudFields(map[string]*framework.FieldSchema{
+	"hrp": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Bech32 prefix of the service addresses: cosmos, osmo, celestia, ... Defaults to cosmos. Fixed on the first key.",
+	},
+})
+
+// createKeyManager handles POST /key-managers/cosmos
+func createKeyManager(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	serviceName, ok := data.Get("name").(string)
+	if !ok || serviceName == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	privInput, ok := data.Get("private_key").(string)
+	if !ok {
+		return nil, fmt.Errorf("private_key must be a string")
+	}
+	privInput = strings.TrimSpace(privInput)
+
+	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.COSMOS, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		km = &types.KeyManager{ServiceName: serviceName}
+	}
+
+	// все адреса сервиса принадлежат одной сети
+	hrp, err := setupHRP(km, strings.TrimSpace(data.Get("hrp").(string)))
+	if err != nil {
+		return nil, err
+	}
+
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive, decode or generate private key
+	var privKey *btcec.PrivateKey
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privInput != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormat)
+		derived, err := hd.DeriveSecp256k1(km.Mnemonic, km.Passphrase, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		privKey, _ = btcec.PrivKeyFromBytes(derived)
+	} else if privInput != "" {
+		bs, err := hex.DecodeString(strings.TrimPrefix(privInput, "0x"))
+		if err != nil || len(bs) != 32 {
+			return nil, fmt.Errorf("invalid private key")
+		}
+		privKey, _ = btcec.PrivKeyFromBytes(bs)
+	} else {
+		privKey, err = btcec.NewPrivateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+	}
+
+	pubKey := privKey.PubKey()
+	address, err := DeriveAddress(pubKey, hrp)
+	if err != nil {
+		return nil, err
+	}
+
+	kp := &types.KeyPair{
+		PrivateKey: hex.EncodeToString(privKey.Serialize()),
+		PublicKey:  hex.EncodeToString(pubKey.SerializeCompressed()),
+		Address:    address,
+	}
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
+	}
+	km.KeyPairs = append(km.KeyPairs, kp)
+
+	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(config.Chain.COSMOS, serviceName), km)
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	respData := map[string]interface{}{
+		"service_name": serviceName,
+		"address":      address,
+		"public_key":   kp.PublicKey,
+		"hrp":          hrp,
+	}
+	backend.AddHDResponseData(respData, kp, generatedMnemonic)
+	return &logical.Response{Data: respData}, nil
+}
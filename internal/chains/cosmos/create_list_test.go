@@ -0,0 +1,105 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestCosmosCreateAndListKeyManagers(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// Import specific privkey
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/cosmos/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "0000000000000000000000000000000000000000000000000000000000000001",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	// hash160 ключа 1 — 751e76e8199196d454941c45d1b3a323f1433bd6
+	assert.Equal(t, "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c", resp.Data["address"])
+	assert.Equal(t, "cosmos", resp.Data["hrp"])
+
+	// Generate another key
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/cosmos/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^cosmos1[02-9ac-hj-np-z]{38}$`, resp.Data["address"])
+
+	// Read
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/cosmos/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 2)
+	assert.Equal(t, "cosmos", resp.Data["hrp"])
+}
+
+func TestCosmosCreateKeyManagers_HRP(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/cosmos/osmosis")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": testMnemonic,
+		"hrp":      "osmo",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "osmo19rl4cm2hmr8afy4kldpxz3fka4jguq0a5m7df8", resp.Data["address"])
+	assert.Equal(t, "m/44'/118'/0'/0/0", resp.Data["derivation_path"])
+
+	// следующий адрес получает тот же префикс, другой префикс отклоняется
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/cosmos/osmosis")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^osmo1`, resp.Data["address"])
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/cosmos/osmosis")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"hrp": "celestia"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+func TestCosmosCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/cosmos/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": testMnemonic,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", resp.Data["address"])
+	assert.Equal(t, "m/44'/118'/0'/0/0", resp.Data["derivation_path"])
+}
+
+func TestCosmosCreateKeyManagers_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	for name, data := range map[string]map[string]interface{}{
+		"invalid private key": {"private_key": "123"},
+		"uppercase hrp":       {"hrp": "Cosmos"},
+		"hrp with dash":       {"hrp": "cosmos-hub"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.CreateOperation, "key-managers/cosmos/svc")
+			req.Storage = storage
+			req.Data = data
+			_, err := b.HandleRequest(context.Background(), req)
+			require.Error(t, err)
+		})
+	}
+}
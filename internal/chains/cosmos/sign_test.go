@@ -0,0 +1,43 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosSignHash(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createCosmosAccount(t, b, storage)
+
+	hash := make([]byte, 32)
+	hash[31] = 1
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/cosmos/svc/sign")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"hash":    hex.EncodeToString(hash),
+		"address": address,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	// 64 байта r||s
+	sig, err := hex.DecodeString(resp.Data["signature"].(string))
+	require.NoError(t, err)
+	require.Len(t, sig, 64)
+	var r, s btcec.ModNScalar
+	r.SetByteSlice(sig[:32])
+	s.SetByteSlice(sig[32:])
+	assert.True(t, ecdsa.NewSignature(&r, &s).Verify(hash, pub))
+
+	req.Data["hash"] = "deadbeef"
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
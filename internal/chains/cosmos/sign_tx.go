@@ -0,0 +1,278 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	signModeNameDirect    = "direct"
+	signModeNameAminoJSON = "amino-json"
+)
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"sign_mode": {
+		Type:        framework.TypeString,
+		Description: "(Optional) direct (default, SIGN_MODE_DIRECT) or amino-json (SIGN_MODE_LEGACY_AMINO_JSON).",
+		Default:     signModeNameDirect,
+	},
+	"sign_doc": {
+		Type:        framework.TypeString,
+		Description: "direct: base64 protobuf SignDoc; amino-json: StdSignDoc JSON.",
+		Default:     "",
+	},
+	"body_bytes": {
+		Type:        framework.TypeString,
+		Description: "(amino-json, optional) Base64 TxBody to assemble TxRaw.",
+		Default:     "",
+	},
+	"auth_info_bytes": {
+		Type:        framework.TypeString,
+		Description: "(amino-json, optional) Base64 AuthInfo to assemble TxRaw.",
+		Default:     "",
+	},
+}
+
+// aminoSignDocKeys — поля StdSignDoc; остальные отклоняются
+var aminoSignDocKeys = map[string]bool{
+	"account_number": true,
+	"chain_id":       true,
+	"fee":            true,
+	"memo":           true,
+	"msgs":           true,
+	"sequence":       true,
+	"timeout_height": true,
+}
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.COSMOS),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signTx},
+		},
+		HelpSynopsis:    "Sign a Cosmos SDK transaction (SIGN_MODE_DIRECT or Amino JSON).",
+		HelpDescription: "POST address, sign_doc [+ sign_mode] → signature (base64 r||s over SHA256 of the sign bytes) and the signed TxRaw (base64) with its hash.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+	signDocInput := strings.TrimSpace(data.Get("sign_doc").(string))
+	if signDocInput == "" {
+		return nil, fmt.Errorf("sign_doc is required")
+	}
+	mode := data.Get("sign_mode").(string)
+	if mode != signModeNameDirect && mode != signModeNameAminoJSON {
+		return nil, fmt.Errorf("unsupported sign_mode %q", mode)
+	}
+
+	kp, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.COSMOS)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing key for address %s: %w", address, err)
+	}
+	privBytes, err := hex.DecodeString(kp.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("stored private key is not valid hex: %w", err)
+	}
+	priv, pub := btcec.PrivKeyFromBytes(privBytes)
+	defer priv.Zero()
+	pubBytes := pub.SerializeCompressed()
+
+	respData := map[string]interface{}{
+		"address":    address,
+		"public_key": base64.StdEncoding.EncodeToString(pubBytes),
+		"sign_mode":  mode,
+	}
+	if mode == signModeNameDirect {
+		err = signDirect(priv, pubBytes, signDocInput, respData)
+	} else {
+		err = signAminoJSON(priv, pubBytes, signDocInput, data, respData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &logical.Response{Data: respData}, nil
+}
+
+// signDirect подписывает SignDoc как есть; ключ должен быть среди signer_infos с режимом DIRECT
+func signDirect(priv *btcec.PrivateKey, pub []byte, input string, out map[string]interface{}) error {
+	raw, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return fmt.Errorf("sign_doc must be base64 encoded")
+	}
+	doc, err := decodeSignDoc(raw)
+	if err != nil {
+		return fmt.Errorf("invalid sign_doc: %w", err)
+	}
+	info, signerIndex, body, err := decodeTx(doc.BodyBytes, doc.AuthInfoBytes, pub, signModeDirect)
+	if err != nil {
+		return err
+	}
+
+	sig := signDigest(priv, raw)
+	addTxResponse(out, doc.BodyBytes, doc.AuthInfoBytes, info, signerIndex, body, sig)
+	out["chain_id"] = doc.ChainID
+	out["account_number"] = doc.AccountNumber
+	out["sequence"] = info.Signers[signerIndex].Sequence
+	return nil
+}
+
+// signAminoJSON подписывает StdSignDoc в канонической форме (ключи по алфавиту, без пробелов);
+// TxRaw собирается, только если переданы body_bytes и auth_info_bytes
+func signAminoJSON(priv *btcec.PrivateKey, pub []byte, input string, data *framework.FieldData, out map[string]interface{}) error {
+	doc, err := parseAminoSignDoc(input)
+	if err != nil {
+		return fmt.Errorf("invalid sign_doc: %w", err)
+	}
+	// json.Marshal сортирует ключи map и экранирует <, >, & — так же, как sdk.MustSortJSON и CosmJS
+	signBytes, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	chainID := doc["chain_id"].(string)
+	accountNumber, _ := strconv.ParseUint(doc["account_number"].(string), 10, 64)
+	sequence, _ := strconv.ParseUint(doc["sequence"].(string), 10, 64)
+
+	bodyInput := strings.TrimSpace(data.Get("body_bytes").(string))
+	authInfoInput := strings.TrimSpace(data.Get("auth_info_bytes").(string))
+	if (bodyInput == "") != (authInfoInput == "") {
+		return fmt.Errorf("body_bytes and auth_info_bytes must be passed together")
+	}
+
+	sig := signDigest(priv, signBytes)
+	if bodyInput != "" {
+		bodyBytes, err := base64.StdEncoding.DecodeString(bodyInput)
+		if err != nil {
+			return fmt.Errorf("body_bytes must be base64 encoded")
+		}
+		authInfoBytes, err := base64.StdEncoding.DecodeString(authInfoInput)
+		if err != nil {
+			return fmt.Errorf("auth_info_bytes must be base64 encoded")
+		}
+		info, signerIndex, body, err := decodeTx(bodyBytes, authInfoBytes, pub, signModeLegacyAminoJSON)
+		if err != nil {
+			return err
+		}
+		// TxRaw должна описывать ту же транзакцию, что и подписанный документ
+		if info.Signers[signerIndex].Sequence != sequence {
+			return fmt.Errorf("auth_info sequence %d does not match sign_doc sequence %d", info.Signers[signerIndex].Sequence, sequence)
+		}
+		if body.Memo != doc["memo"] {
+			return fmt.Errorf("body_bytes memo does not match sign_doc memo")
+		}
+		addTxResponse(out, bodyBytes, authInfoBytes, info, signerIndex, body, sig)
+	} else {
+		out["signature"] = base64.StdEncoding.EncodeToString(sig)
+	}
+	out["sign_bytes"] = string(signBytes)
+	out["chain_id"] = chainID
+	out["account_number"] = accountNumber
+	out["sequence"] = sequence
+	return nil
+}
+
+// decodeTx разбирает TxBody и AuthInfo и находит ключ сервиса среди подписантов с нужным режимом
+func decodeTx(bodyBytes, authInfoBytes, pub []byte, mode uint64) (*authInfo, int, *txBody, error) {
+	info, err := decodeAuthInfo(authInfoBytes)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	body, err := decodeTxBody(bodyBytes)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	for i, signer := range info.Signers {
+		if !bytes.Equal(signer.PubKey, pub) {
+			continue
+		}
+		if signer.Mode != mode {
+			return nil, 0, nil, fmt.Errorf("signer_infos[%d] has sign mode %d, expected %d", i, signer.Mode, mode)
+		}
+		return info, i, body, nil
+	}
+	return nil, 0, nil, fmt.Errorf("the key is not among the signer_infos of auth_info")
+}
+
+// addTxResponse кладёт подпись в слот подписанта TxRaw; остальные слоты пустые, их заполняют другие подписанты
+func addTxResponse(out map[string]interface{}, bodyBytes, authInfoBytes []byte, info *authInfo, signerIndex int, body *txBody, sig []byte) {
+	signatures := make([][]byte, len(info.Signers))
+	signatures[signerIndex] = sig
+	txRaw := encodeTxRaw(bodyBytes, authInfoBytes, signatures)
+	txHash := sha256.Sum256(txRaw)
+
+	out["signature"] = base64.StdEncoding.EncodeToString(sig)
+	out["tx_raw"] = base64.StdEncoding.EncodeToString(txRaw)
+	out["tx_hash"] = strings.ToUpper(hex.EncodeToString(txHash[:]))
+	out["signer_index"] = signerIndex
+	out["fully_signed"] = len(info.Signers) == 1
+	out["messages"] = body.Messages
+	out["memo"] = body.Memo
+	out["fee"] = info.Fee
+	out["gas_limit"] = info.GasLimit
+}
+
+// parseAminoSignDoc проверяет StdSignDoc: объект с chain_id, account_number, sequence (строки-числа), fee, memo и msgs
+func parseAminoSignDoc(input string) (map[string]interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(input))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("trailing data after the JSON object")
+	}
+	for key := range doc {
+		if !aminoSignDocKeys[key] {
+			return nil, fmt.Errorf("unexpected field %q", key)
+		}
+	}
+	if chainID, ok := doc["chain_id"].(string); !ok || chainID == "" {
+		return nil, fmt.Errorf("chain_id is required")
+	}
+	for _, key := range []string{"account_number", "sequence"} {
+		value, ok := doc[key].(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a decimal string", key)
+		}
+		if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("%s must be a decimal string", key)
+		}
+	}
+	if _, ok := doc["fee"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("fee is required")
+	}
+	if _, ok := doc["memo"].(string); !ok {
+		return nil, fmt.Errorf("memo must be a string")
+	}
+	if msgs, ok := doc["msgs"].([]interface{}); !ok || len(msgs) == 0 {
+		return nil, fmt.Errorf("msgs must be a non-empty array")
+	}
+	return doc, nil
+}
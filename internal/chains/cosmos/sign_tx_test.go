@@ -0,0 +1,233 @@
+package cosmos_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendBytesField(b []byte, num protowire.Number, value []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
+
+func appendVarintField(b []byte, num protowire.Number, value uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+func anyMsg(typeURL string, value []byte) []byte {
+	return appendBytesField(appendBytesField(nil, 1, []byte(typeURL)), 2, value)
+}
+
+func coin(denom, amount string) []byte {
+	return appendBytesField(appendBytesField(nil, 1, []byte(denom)), 2, []byte(amount))
+}
+
+// txBodyBytes — TxBody с одним MsgSend и memo
+func txBodyBytes(from, to, memo string) []byte {
+	send := appendBytesField(nil, 1, []byte(from))
+	send = appendBytesField(send, 2, []byte(to))
+	send = appendBytesField(send, 3, coin("uatom", "1000"))
+	body := appendBytesField(nil, 1, anyMsg("/cosmos.bank.v1beta1.MsgSend", send))
+	return appendBytesField(body, 2, []byte(memo))
+}
+
+// authInfoBytes — AuthInfo с подписантами (secp256k1 ключ, single mode, sequence) и комиссией
+func authInfoBytes(mode uint64, sequence uint64, pubKeys ...[]byte) []byte {
+	var info []byte
+	for _, pub := range pubKeys {
+		signer := appendBytesField(nil, 1, anyMsg("/cosmos.crypto.secp256k1.PubKey", appendBytesField(nil, 1, pub)))
+		signer = appendBytesField(signer, 2, appendBytesField(nil, 1, appendVarintField(nil, 1, mode)))
+		signer = appendVarintField(signer, 3, sequence)
+		info = appendBytesField(info, 1, signer)
+	}
+	fee := appendBytesField(nil, 1, coin("uatom", "500"))
+	fee = appendVarintField(fee, 2, 200000)
+	return appendBytesField(info, 2, fee)
+}
+
+func signDocBytes(body, authInfo []byte, chainID string, accountNumber uint64) []byte {
+	doc := appendBytesField(nil, 1, body)
+	doc = appendBytesField(doc, 2, authInfo)
+	doc = appendBytesField(doc, 3, []byte(chainID))
+	if accountNumber != 0 {
+		doc = appendVarintField(doc, 4, accountNumber)
+	}
+	return doc
+}
+
+func createCosmosAccount(t *testing.T, b logical.Backend, storage logical.Storage) (string, *btcec.PublicKey) {
+	t.Helper()
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/cosmos/svc")
+	req.Storage = storage
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pubBytes, err := hex.DecodeString(resp.Data["public_key"].(string))
+	require.NoError(t, err)
+	pub, err := btcec.ParsePubKey(pubBytes)
+	require.NoError(t, err)
+	return resp.Data["address"].(string), pub
+}
+
+func signCosmosTx(t *testing.T, b logical.Backend, storage logical.Storage, data map[string]interface{}) (*logical.Response, error) {
+	t.Helper()
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/cosmos/svc/sign-tx")
+	req.Storage = storage
+	req.Data = data
+	return b.HandleRequest(context.Background(), req)
+}
+
+// verifyCompact проверяет подпись r||s над SHA256(msg)
+func verifyCompact(t *testing.T, pub *btcec.PublicKey, msg []byte, sig []byte) {
+	t.Helper()
+	require.Len(t, sig, 64)
+	var r, s btcec.ModNScalar
+	r.SetByteSlice(sig[:32])
+	s.SetByteSlice(sig[32:])
+	assert.False(t, s.IsOverHalfOrder(), "signature must be low-S")
+	digest := sha256.Sum256(msg)
+	assert.True(t, ecdsa.NewSignature(&r, &s).Verify(digest[:], pub))
+}
+
+func TestCosmosSignTx_Direct(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createCosmosAccount(t, b, storage)
+	other, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	body := txBodyBytes(address, "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c", "payout #1")
+	// ключ сервиса — второй из двух подписантов
+	authInfo := authInfoBytes(1, 7, other.PubKey().SerializeCompressed(), pub.SerializeCompressed())
+	doc := signDocBytes(body, authInfo, "cosmoshub-4", 12345)
+
+	resp, err := signCosmosTx(t, b, storage, map[string]interface{}{
+		"address":  address,
+		"sign_doc": base64.StdEncoding.EncodeToString(doc),
+	})
+	require.NoError(t, err)
+
+	sig, err := base64.StdEncoding.DecodeString(resp.Data["signature"].(string))
+	require.NoError(t, err)
+	verifyCompact(t, pub, doc, sig)
+
+	assert.Equal(t, "cosmoshub-4", resp.Data["chain_id"])
+	assert.Equal(t, uint64(12345), resp.Data["account_number"])
+	assert.Equal(t, uint64(7), resp.Data["sequence"])
+	assert.Equal(t, 1, resp.Data["signer_index"])
+	assert.Equal(t, false, resp.Data["fully_signed"])
+	assert.Equal(t, "payout #1", resp.Data["memo"])
+	assert.Equal(t, uint64(200000), resp.Data["gas_limit"])
+	assert.Equal(t, []map[string]string{{"denom": "uatom", "amount": "500"}}, resp.Data["fee"])
+
+	messages := resp.Data["messages"].([]map[string]interface{})
+	require.Len(t, messages, 1)
+	assert.Equal(t, "/cosmos.bank.v1beta1.MsgSend", messages[0]["type_url"])
+	assert.Equal(t, address, messages[0]["from_address"])
+	assert.Equal(t, []map[string]string{{"denom": "uatom", "amount": "1000"}}, messages[0]["amount"])
+
+	// TxRaw: body, auth_info и подпись во втором слоте, первый пустой
+	txRaw, err := base64.StdEncoding.DecodeString(resp.Data["tx_raw"].(string))
+	require.NoError(t, err)
+	expected := appendBytesField(appendBytesField(nil, 1, body), 2, authInfo)
+	expected = appendBytesField(appendBytesField(expected, 3, nil), 3, sig)
+	assert.Equal(t, expected, txRaw)
+	txHash := sha256.Sum256(txRaw)
+	assert.Equal(t, strings.ToUpper(hex.EncodeToString(txHash[:])), resp.Data["tx_hash"])
+}
+
+func TestCosmosSignTx_AminoJSON(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createCosmosAccount(t, b, storage)
+
+	// ключи не по порядку и с пробелами: подписывается каноническая форма, "<" экранируется как \u003c
+	signDoc := `{
+		"msgs": [{"type": "cosmos-sdk/MsgSend", "value": {"to_address": "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c", "from_address": "` + address + `", "amount": [{"denom": "uatom", "amount": "1000"}]}}],
+		"memo": "a<b",
+		"fee": {"gas": "200000", "amount": [{"denom": "uatom", "amount": "500"}]},
+		"sequence": "7",
+		"chain_id": "cosmoshub-4",
+		"account_number": "12345"
+	}`
+	canonical := `{"account_number":"12345","chain_id":"cosmoshub-4","fee":{"amount":[{"amount":"500","denom":"uatom"}],"gas":"200000"},` +
+		`"memo":"a\u003cb","msgs":[{"type":"cosmos-sdk/MsgSend","value":{"amount":[{"amount":"1000","denom":"uatom"}],` +
+		`"from_address":"` + address + `","to_address":"cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c"}}],"sequence":"7"}`
+
+	// только подпись
+	resp, err := signCosmosTx(t, b, storage, map[string]interface{}{
+		"address":   address,
+		"sign_mode": "amino-json",
+		"sign_doc":  signDoc,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, canonical, resp.Data["sign_bytes"])
+	sig, err := base64.StdEncoding.DecodeString(resp.Data["signature"].(string))
+	require.NoError(t, err)
+	verifyCompact(t, pub, []byte(canonical), sig)
+	assert.NotContains(t, resp.Data, "tx_raw")
+
+	// с body и auth_info собирается TxRaw
+	body := txBodyBytes(address, "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c", "a<b")
+	authInfo := authInfoBytes(127, 7, pub.SerializeCompressed())
+	resp, err = signCosmosTx(t, b, storage, map[string]interface{}{
+		"address":         address,
+		"sign_mode":       "amino-json",
+		"sign_doc":        signDoc,
+		"body_bytes":      base64.StdEncoding.EncodeToString(body),
+		"auth_info_bytes": base64.StdEncoding.EncodeToString(authInfo),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.Data["fully_signed"])
+	txRaw, err := base64.StdEncoding.DecodeString(resp.Data["tx_raw"].(string))
+	require.NoError(t, err)
+	expected := appendBytesField(appendBytesField(nil, 1, body), 2, authInfo)
+	assert.Equal(t, appendBytesField(expected, 3, sig), txRaw)
+}
+
+func TestCosmosSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createCosmosAccount(t, b, storage)
+	other, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	body := txBodyBytes(address, address, "")
+	direct := func(doc []byte) map[string]interface{} {
+		return map[string]interface{}{"address": address, "sign_doc": base64.StdEncoding.EncodeToString(doc)}
+	}
+	amino := `{"account_number":"1","chain_id":"c","fee":{"amount":[],"gas":"1"},"memo":"","msgs":[{}],"sequence":"7"}`
+
+	for name, data := range map[string]map[string]interface{}{
+		"not a signer":  direct(signDocBytes(body, authInfoBytes(1, 0, other.PubKey().SerializeCompressed()), "c", 1)),
+		"amino mode":    direct(signDocBytes(body, authInfoBytes(127, 0, pub.SerializeCompressed()), "c", 1)),
+		"no chain id":   direct(signDocBytes(body, authInfoBytes(1, 0, pub.SerializeCompressed()), "", 1)),
+		"non canonical": direct(appendVarintField(signDocBytes(body, authInfoBytes(1, 0, pub.SerializeCompressed()), "c", 0), 4, 0)),
+		"not base64":    {"address": address, "sign_doc": "%%%"},
+		"empty":         {"address": address, "sign_doc": ""},
+		"unknown mode":  {"address": address, "sign_doc": amino, "sign_mode": "textual"},
+		"amino numeric sequence": {"address": address, "sign_mode": "amino-json",
+			"sign_doc": strings.Replace(amino, `"7"`, `7`, 1)},
+		"amino unknown field": {"address": address, "sign_mode": "amino-json",
+			"sign_doc": strings.Replace(amino, `"memo"`, `"extra":1,"memo"`, 1)},
+		"amino sequence mismatch": {"address": address, "sign_mode": "amino-json", "sign_doc": amino,
+			"body_bytes":      base64.StdEncoding.EncodeToString(body),
+			"auth_info_bytes": base64.StdEncoding.EncodeToString(authInfoBytes(127, 8, pub.SerializeCompressed()))},
+		"amino body without auth info": {"address": address, "sign_mode": "amino-json", "sign_doc": amino,
+			"body_bytes": base64.StdEncoding.EncodeToString(body)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := signCosmosTx(t, b, storage, data)
+			require.Error(t, err)
+		})
+	}
+}
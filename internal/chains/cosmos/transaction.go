@@ -0,0 +1,338 @@
+package cosmos
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Номера полей protobuf Cosmos SDK (cosmos/tx/v1beta1/tx.proto, cosmos/base/v1beta1/coin.proto)
+const (
+	// SignDoc; у TxRaw поля 1 и 2 те же, 3 — signatures
+	signDocFieldBodyBytes     = 1
+	signDocFieldAuthInfoBytes = 2
+	signDocFieldChainID       = 3
+	signDocFieldAccountNumber = 4
+	txRawFieldSignatures      = 3
+
+	bodyFieldMessages      = 1
+	bodyFieldMemo          = 2
+	bodyFieldTimeoutHeight = 3
+
+	authInfoFieldSignerInfos = 1
+	authInfoFieldFee         = 2
+
+	signerInfoFieldPublicKey = 1
+	signerInfoFieldModeInfo  = 2
+	signerInfoFieldSequence  = 3
+
+	modeInfoFieldSingle = 1
+	singleFieldMode     = 1
+
+	feeFieldAmount   = 1
+	feeFieldGasLimit = 2
+
+	coinFieldDenom  = 1
+	coinFieldAmount = 2
+
+	anyFieldTypeURL = 1
+	anyFieldValue   = 2
+
+	pubKeyFieldKey = 1
+
+	msgSendFieldFrom   = 1
+	msgSendFieldTo     = 2
+	msgSendFieldAmount = 3
+)
+
+// Режимы подписи из cosmos.tx.signing.v1beta1.SignMode
+const (
+	signModeDirect          = 1
+	signModeLegacyAminoJSON = 127
+)
+
+const (
+	secp256k1PubKeyTypeURL = "/cosmos.crypto.secp256k1.PubKey"
+	msgSendTypeURL         = "/cosmos.bank.v1beta1.MsgSend"
+)
+
+// signDoc — SIGN_MODE_DIRECT документ, подписывается его сериализация целиком
+type signDoc struct {
+	BodyBytes     []byte
+	AuthInfoBytes []byte
+	ChainID       string
+	AccountNumber uint64
+}
+
+// signerInfo — подписант из AuthInfo; PubKey заполнен только для secp256k1 ключей, Mode — только для single
+type signerInfo struct {
+	PubKey   []byte
+	Mode     uint64
+	Sequence uint64
+}
+
+type authInfo struct {
+	Signers  []signerInfo
+	Fee      []map[string]string
+	GasLimit uint64
+}
+
+type txBody struct {
+	Messages      []map[string]interface{}
+	Memo          string
+	TimeoutHeight uint64
+}
+
+// decodeSignDoc разбирает SignDoc и требует каноническую сериализацию: именно её пересоберёт и проверит узел
+func decodeSignDoc(raw []byte) (*signDoc, error) {
+	doc := &signDoc{}
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == signDocFieldBodyBytes && typ == protowire.BytesType:
+			doc.BodyBytes = value
+		case num == signDocFieldAuthInfoBytes && typ == protowire.BytesType:
+			doc.AuthInfoBytes = value
+		case num == signDocFieldChainID && typ == protowire.BytesType:
+			doc.ChainID = string(value)
+		case num == signDocFieldAccountNumber && typ == protowire.VarintType:
+			doc.AccountNumber = varint
+		default:
+			return fmt.Errorf("unexpected field %d", num)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.BodyBytes) == 0 || len(doc.AuthInfoBytes) == 0 || doc.ChainID == "" {
+		return nil, errors.New("body_bytes, auth_info_bytes and chain_id are required")
+	}
+	if !bytes.Equal(doc.encode(), raw) {
+		return nil, errors.New("sign_doc is not canonically encoded")
+	}
+	return doc, nil
+}
+
+func (d *signDoc) encode() []byte {
+	var out []byte
+	out = protowire.AppendTag(out, signDocFieldBodyBytes, protowire.BytesType)
+	out = protowire.AppendBytes(out, d.BodyBytes)
+	out = protowire.AppendTag(out, signDocFieldAuthInfoBytes, protowire.BytesType)
+	out = protowire.AppendBytes(out, d.AuthInfoBytes)
+	out = protowire.AppendTag(out, signDocFieldChainID, protowire.BytesType)
+	out = protowire.AppendString(out, d.ChainID)
+	if d.AccountNumber != 0 {
+		out = protowire.AppendTag(out, signDocFieldAccountNumber, protowire.VarintType)
+		out = protowire.AppendVarint(out, d.AccountNumber)
+	}
+	return out
+}
+
+// encodeTxRaw собирает TxRaw — байты для broadcast
+func encodeTxRaw(body, authInfo []byte, signatures [][]byte) []byte {
+	var out []byte
+	out = protowire.AppendTag(out, signDocFieldBodyBytes, protowire.BytesType)
+	out = protowire.AppendBytes(out, body)
+	out = protowire.AppendTag(out, signDocFieldAuthInfoBytes, protowire.BytesType)
+	out = protowire.AppendBytes(out, authInfo)
+	for _, sig := range signatures {
+		out = protowire.AppendTag(out, txRawFieldSignatures, protowire.BytesType)
+		out = protowire.AppendBytes(out, sig)
+	}
+	return out
+}
+
+func decodeAuthInfo(raw []byte) (*authInfo, error) {
+	info := &authInfo{}
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		switch {
+		case num == authInfoFieldSignerInfos && typ == protowire.BytesType:
+			signer, err := decodeSignerInfo(value)
+			if err != nil {
+				return err
+			}
+			info.Signers = append(info.Signers, *signer)
+		case num == authInfoFieldFee && typ == protowire.BytesType:
+			return walkFields(value, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+				switch {
+				case num == feeFieldAmount && typ == protowire.BytesType:
+					coin, err := decodeCoin(value)
+					if err != nil {
+						return err
+					}
+					info.Fee = append(info.Fee, coin)
+				case num == feeFieldGasLimit && typ == protowire.VarintType:
+					info.GasLimit = varint
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth_info: %w", err)
+	}
+	if len(info.Signers) == 0 {
+		return nil, errors.New("invalid auth_info: no signer_infos")
+	}
+	return info, nil
+}
+
+func decodeSignerInfo(raw []byte) (*signerInfo, error) {
+	signer := &signerInfo{}
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == signerInfoFieldPublicKey && typ == protowire.BytesType:
+			typeURL, anyValue, err := decodeAny(value)
+			if err != nil {
+				return err
+			}
+			// ключи других типов (multisig, ed25519) не могут совпасть с ключом сервиса
+			if typeURL != secp256k1PubKeyTypeURL {
+				return nil
+			}
+			return walkFields(anyValue, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+				if num == pubKeyFieldKey && typ == protowire.BytesType {
+					signer.PubKey = value
+				}
+				return nil
+			})
+		case num == signerInfoFieldModeInfo && typ == protowire.BytesType:
+			return walkFields(value, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+				if num != modeInfoFieldSingle || typ != protowire.BytesType {
+					return nil
+				}
+				return walkFields(value, func(num protowire.Number, typ protowire.Type, _ []byte, varint uint64) error {
+					if num == singleFieldMode && typ == protowire.VarintType {
+						signer.Mode = varint
+					}
+					return nil
+				})
+			})
+		case num == signerInfoFieldSequence && typ == protowire.VarintType:
+			signer.Sequence = varint
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// decodeTxBody возвращает memo и сообщения: type_url у всех, поля — у MsgSend
+func decodeTxBody(raw []byte) (*txBody, error) {
+	body := &txBody{}
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == bodyFieldMessages && typ == protowire.BytesType:
+			typeURL, anyValue, err := decodeAny(value)
+			if err != nil {
+				return err
+			}
+			msg := map[string]interface{}{"type_url": typeURL}
+			if typeURL == msgSendTypeURL {
+				if err := decodeMsgSend(anyValue, msg); err != nil {
+					return err
+				}
+			}
+			body.Messages = append(body.Messages, msg)
+		case num == bodyFieldMemo && typ == protowire.BytesType:
+			body.Memo = string(value)
+		case num == bodyFieldTimeoutHeight && typ == protowire.VarintType:
+			body.TimeoutHeight = varint
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid body_bytes: %w", err)
+	}
+	if len(body.Messages) == 0 {
+		return nil, errors.New("invalid body_bytes: no messages")
+	}
+	return body, nil
+}
+
+func decodeMsgSend(raw []byte, msg map[string]interface{}) error {
+	amount := []map[string]string{}
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		switch {
+		case num == msgSendFieldFrom && typ == protowire.BytesType:
+			msg["from_address"] = string(value)
+		case num == msgSendFieldTo && typ == protowire.BytesType:
+			msg["to_address"] = string(value)
+		case num == msgSendFieldAmount && typ == protowire.BytesType:
+			coin, err := decodeCoin(value)
+			if err != nil {
+				return err
+			}
+			amount = append(amount, coin)
+		}
+		return nil
+	})
+	msg["amount"] = amount
+	return err
+}
+
+func decodeCoin(raw []byte) (map[string]string, error) {
+	coin := map[string]string{"denom": "", "amount": ""}
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+		switch {
+		case num == coinFieldDenom && typ == protowire.BytesType:
+			coin["denom"] = string(value)
+		case num == coinFieldAmount && typ == protowire.BytesType:
+			coin["amount"] = string(value)
+		}
+		return nil
+	})
+	return coin, err
+}
+
+// decodeAny разбирает google.protobuf.Any
+func decodeAny(raw []byte) (string, []byte, error) {
+	var typeURL string
+	var value []byte
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+		switch {
+		case num == anyFieldTypeURL && typ == protowire.BytesType:
+			typeURL = string(v)
+		case num == anyFieldValue && typ == protowire.BytesType:
+			value = v
+		}
+		return nil
+	})
+	return typeURL, value, err
+}
+
+// walkFields обходит поля protobuf-сообщения; для bytes передаётся value, для varint — varint
+func walkFields(msg []byte, fn func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+
+		var value []byte
+		var varint uint64
+		switch typ {
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(msg)
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(msg)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, msg)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+
+		if err := fn(num, typ, value, varint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
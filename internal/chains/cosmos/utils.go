@@ -0,0 +1,62 @@
+package cosmos
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// hdPathFormat — BIP-44 путь Cosmos Hub (coin type 118), его же используют Osmosis, Celestia и др.; %d — индекс адреса
+const hdPathFormat = "m/44'/118'/0'/0/%d"
+
+// defaultHRP — префикс адресов сервиса, если hrp не передан
+const defaultHRP = "cosmos"
+
+// hrpPattern — bech32 HRP сетей Cosmos SDK: строчные буквы и цифры, начинается с буквы
+var hrpPattern = regexp.MustCompile(`^[a-z][a-z0-9]{0,82}$`)
+
+// setupHRP fixes the bech32 prefix of a key-manager, the same way SetupNetwork fixes its network:
+// a new service takes the requested prefix or cosmos, an existing one rejects a different prefix.
+func setupHRP(km *types.KeyManager, requested string) (string, error) {
+	if requested != "" && !hrpPattern.MatchString(requested) {
+		return "", fmt.Errorf("invalid hrp %q: lowercase letters and digits only", requested)
+	}
+	if km.HRP != "" {
+		if requested != "" && requested != km.HRP {
+			return "", fmt.Errorf("key-manager %s uses hrp %s, not %s", km.ServiceName, km.HRP, requested)
+		}
+		return km.HRP, nil
+	}
+	if requested == "" {
+		requested = defaultHRP
+	}
+	km.HRP = requested
+	return requested, nil
+}
+
+// DeriveAddress builds the bech32 account address: RIPEMD160(SHA256(compressed pubkey)) with the service HRP
+func DeriveAddress(pub *btcec.PublicKey, hrp string) (string, error) {
+	conv, err := bech32.ConvertBits(btcutil.Hash160(pub.SerializeCompressed()), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode(hrp, conv)
+}
+
+// signDigest подписывает SHA256(msg) и возвращает 64 байта r||s с low-S, как ожидает Cosmos SDK
+func signDigest(priv *btcec.PrivateKey, msg []byte) []byte {
+	digest := sha256.Sum256(msg)
+	return signHash32(priv, digest[:])
+}
+
+func signHash32(priv *btcec.PrivateKey, hash []byte) []byte {
+	// компактная подпись: байт восстановления + r + s; сам байт Cosmos не нужен
+	compact := ecdsa.SignCompact(priv, hash, true)
+	return compact[1:]
+}
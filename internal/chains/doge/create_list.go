@@ -2,7 +2,6 @@ package doge
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -35,10 +34,17 @@ func PathCrud() *framework.Path {
 		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.DOGE),
 		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
 		HelpDescription: backend.DefaultHelpDescriptionCreateList,
-		Fields:          backend.DefaultCrudOperations,
+		Fields:          createFields,
 	}
 }
 
+var createFields = backend.CrudFields(map[string]*framework.FieldSchema{
+	"network": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Network of the service: mainnet or testnet. Defaults to config/doge, then mainnet. Fixed on the first key.",
+	},
+})
+
 // createKeyManager handles POST /key-managers/doge
 func createKeyManager(
 	ctx context.Context,
@@ -56,6 +62,10 @@ func createKeyManager(
 		return nil, fmt.Errorf("private_key must be a string")
 	}
 	privInput = strings.TrimSpace(privInput)
+	requestedNetwork := strings.TrimSpace(data.Get("network").(string))
+	if _, err := networkParams(requestedNetwork); err != nil {
+		return nil, err
+	}
 
 	// retrieve or init key-manager
 	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.DOGE, serviceName)
@@ -66,51 +76,34 @@ func createKeyManager(
 		km = &types.KeyManager{ServiceName: serviceName}
 	}
 
-	// decode or generate private key
-	var privKey *btcec.PrivateKey
-	if privInput != "" {
-		// try WIF
-		if wif, err := btcutil.DecodeWIF(privInput); err == nil {
-			privKey = wif.PrivKey
-		} else if bs, err := hex.DecodeString(strings.TrimPrefix(privInput, "0x")); err == nil && len(bs) == 32 {
-			// raw hex seed
-			privKey, _ = btcec.PrivKeyFromBytes(bs)
-		}
-		if privKey == nil {
-			return nil, fmt.Errorf("invalid private key")
-		}
-	} else {
-		// new random key
-		privKey, _ = btcec.NewPrivateKey()
+	cfg, err := backend.GetChainConfig(ctx, req.Storage, config.Chain.DOGE)
+	if err != nil {
+		return nil, err
 	}
-
-	// derive public key and address
-	pubKey := privKey.PubKey()
-	address, err := DeriveAddress(pubKey)
+	network, err := backend.SetupNetwork(km, requestedNetwork, cfg.Network)
 	if err != nil {
 		return nil, err
 	}
-
-	// store key-pair
-	kp := &types.KeyPair{
-		PrivateKey: hex.EncodeToString(privKey.Serialize()),
-		PublicKey:  hex.EncodeToString(pubKey.SerializeCompressed()),
-		Address:    address,
+	params, err := networkParams(network)
+	if err != nil {
+		return nil, err
 	}
-	km.KeyPairs = append(km.KeyPairs, kp)
 
-	// persist
-	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(config.Chain.DOGE, serviceName), km)
-	if err := req.Storage.Put(ctx, entry); err != nil {
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
 		return nil, err
 	}
 
-	// response
-	return &logical.Response{
-		Data: map[string]interface{}{
-			"service_name": serviceName,
-			"address":      address,
-			"public_key":   kp.PublicKey,
+	return backend.CreateUTXOKeyPair(ctx, req, km, privInput, generatedMnemonic, backend.UTXOKeySpec{
+		Chain:      config.Chain.DOGE,
+		Network:    network,
+		Params:     params,
+		PathFormat: fmt.Sprintf(hdPathFormat, params.HDCoinType),
+		Address: func(pub *btcec.PublicKey) (string, error) {
+			return DeriveAddress(pub, params)
+		},
+		WIFForNet: func(wif *btcutil.WIF) bool {
+			return isWIFForNet(wif, params)
 		},
-	}, nil
+	})
 }
@@ -69,3 +69,41 @@ func TestDogeCreateAndListKeyManagers3(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid private key")
 }
+
+func TestDogeCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/doge/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "DBus3bamQjgJULBJtYXpEzDWQRwF5iwxgC", resp.Data["address"])
+	assert.Equal(t, "m/44'/3'/0'/0/0", resp.Data["derivation_path"])
+}
+
+func TestDogeCreateKeyManagers_Testnet(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/doge/staging")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"network":  "testnet",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/1'/0'/0/0", resp.Data["derivation_path"])
+	assert.Equal(t, "testnet", resp.Data["network"])
+	assert.Regexp(t, `^n[1-9A-HJ-NP-Za-km-z]{33}$`, resp.Data["address"])
+
+	// Bitcoin mainnet WIF is accepted on mainnet only
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/doge/imported")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "KzQJ9vR4JeoJicejXmdvjcoDmZHa665diNxt17o3KRw3Hvix5CA5",
+		"network":     "testnet",
+	}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
@@ -0,0 +1,192 @@
+package doge
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"tx": {
+		Type:        framework.TypeString,
+		Description: "Unsigned raw transaction (hex).",
+		Default:     "",
+	},
+	"prev_outputs": {
+		Type:        framework.TypeSlice,
+		Description: `Outputs spent by the inputs, in input order: [{"script": "76a914…88ac", "amount": 100000000}] (amount in koinu).`,
+	},
+}
+
+// maxKoinu — MAX_MONEY Dogecoin Core (10 млрд DOGE), больше чем btcutil.MaxSatoshi
+const maxKoinu = 10_000_000_000 * 1e8
+
+// prevOutput — выход, который тратит вход транзакции
+type prevOutput struct {
+	Script string `json:"script"`
+	Amount *int64 `json:"amount"`
+}
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.DOGE),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signTx},
+		},
+		HelpSynopsis:    "Sign P2PKH inputs of a raw Dogecoin transaction.",
+		HelpDescription: "POST tx(hex) + prev_outputs → tx(hex) with SIGHASH_ALL scriptSigs for every input owned by the service, txid and fee.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name, ok := data.Get("name").(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid request data: missing or invalid 'name' field")
+	}
+	rawTx, err := hex.DecodeString(strings.TrimSpace(data.Get("tx").(string)))
+	if err != nil || len(rawTx) == 0 {
+		return nil, fmt.Errorf("invalid request data: tx must be a hex raw transaction")
+	}
+	// у Dogecoin нет segwit: только legacy-сериализация
+	var tx wire.MsgTx
+	if err := tx.DeserializeNoWitness(bytes.NewReader(rawTx)); err != nil {
+		return nil, fmt.Errorf("invalid tx: %w", err)
+	}
+	prevScripts, inputTotal, err := parsePrevOutputs(data.Get("prev_outputs"), len(tx.TxIn))
+	if err != nil {
+		return nil, err
+	}
+
+	var outputTotal int64
+	for i, out := range tx.TxOut {
+		if out.Value < 0 || out.Value > maxKoinu {
+			return nil, fmt.Errorf("output %d has an invalid amount", i)
+		}
+		// как MoneyRange в Dogecoin Core: сумма тоже не больше MAX_MONEY, поэтому int64 не переполняется
+		outputTotal += out.Value
+		if outputTotal > maxKoinu {
+			return nil, fmt.Errorf("outputs exceed the maximum money supply")
+		}
+	}
+	if outputTotal > inputTotal {
+		return nil, fmt.Errorf("outputs (%d) exceed inputs (%d)", outputTotal, inputTotal)
+	}
+
+	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.DOGE, name)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		return nil, fmt.Errorf("key-manager %s does not exist", name)
+	}
+	signers, err := p2pkhSigners(km.KeyPairs)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := make([]int, 0, len(tx.TxIn))
+	for i, in := range tx.TxIn {
+		priv, ok := signers[hex.EncodeToString(prevScripts[i])]
+		if !ok || len(in.SignatureScript) > 0 {
+			continue
+		}
+		// legacy sighash: scriptCode = P2PKH-скрипт выхода, SIGHASH_ALL, сжатый ключ в scriptSig
+		scriptSig, err := txscript.SignatureScript(&tx, i, prevScripts[i], txscript.SigHashAll, priv, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign input %d: %w", i, err)
+		}
+		in.SignatureScript = scriptSig
+		signed = append(signed, i)
+	}
+	if len(signed) == 0 {
+		return nil, errors.New("no inputs belong to the key-manager")
+	}
+
+	var buf bytes.Buffer
+	if err := tx.SerializeNoWitness(&buf); err != nil {
+		return nil, err
+	}
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"tx":            hex.EncodeToString(buf.Bytes()),
+			"txid":          tx.TxHash().String(),
+			"signed_inputs": signed,
+			"fee":           inputTotal - outputTotal,
+		},
+	}, nil
+}
+
+// parsePrevOutputs разбирает prev_outputs: ровно по одному на вход; возвращает скрипты и сумму входов
+func parsePrevOutputs(raw interface{}, inputs int) ([][]byte, int64, error) {
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid prev_outputs: %w", err)
+	}
+	var items []prevOutput
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&items); err != nil {
+		return nil, 0, fmt.Errorf("invalid prev_outputs: %w", err)
+	}
+	if len(items) != inputs {
+		return nil, 0, fmt.Errorf("prev_outputs must have one entry per input: got %d, want %d", len(items), inputs)
+	}
+
+	scripts := make([][]byte, 0, len(items))
+	var total int64
+	for i, item := range items {
+		script, err := hex.DecodeString(item.Script)
+		if err != nil || len(script) == 0 {
+			return nil, 0, fmt.Errorf("prev_outputs[%d]: script must be hex", i)
+		}
+		if item.Amount == nil || *item.Amount < 0 || *item.Amount > maxKoinu {
+			return nil, 0, fmt.Errorf("prev_outputs[%d]: amount is required and must be a non-negative number of koinu", i)
+		}
+		scripts = append(scripts, script)
+		total += *item.Amount
+		if total > maxKoinu {
+			return nil, 0, fmt.Errorf("prev_outputs: total amount exceeds the maximum money supply")
+		}
+	}
+	return scripts, total, nil
+}
+
+// p2pkhSigners сопоставляет P2PKH-скрипты адресов сервиса с их ключами
+func p2pkhSigners(pairs []*types.KeyPair) (map[string]*btcec.PrivateKey, error) {
+	signers := make(map[string]*btcec.PrivateKey, len(pairs))
+	for _, kp := range pairs {
+		privBytes, err := hex.DecodeString(kp.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("stored private key is not valid hex: %w", err)
+		}
+		priv, pub := btcec.PrivKeyFromBytes(privBytes)
+		script, err := txscript.NewScriptBuilder().
+			AddOp(txscript.OP_DUP).
+			AddOp(txscript.OP_HASH160).
+			AddData(btcutil.Hash160(pub.SerializeCompressed())).
+			AddOp(txscript.OP_EQUALVERIFY).
+			AddOp(txscript.OP_CHECKSIG).
+			Script()
+		if err != nil {
+			return nil, err
+		}
+		signers[hex.EncodeToString(script)] = priv
+	}
+	return signers, nil
+}
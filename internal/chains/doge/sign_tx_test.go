@@ -0,0 +1,135 @@
+package doge_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// p2pkhScript строит скрипт выхода по Base58Check-адресу (версия 1 байт + hash160)
+func p2pkhScript(t *testing.T, address string) []byte {
+	t.Helper()
+	decoded, _, err := base58.CheckDecode(address)
+	require.NoError(t, err)
+	script, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).AddData(decoded).
+		AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG).Script()
+	require.NoError(t, err)
+	return script
+}
+
+func unsignedTx(t *testing.T, inputs int, outputScript []byte, value int64) string {
+	t.Helper()
+	tx := wire.NewMsgTx(1)
+	for i := 0; i < inputs; i++ {
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{byte(i + 1)}, uint32(i)), nil, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(value, outputScript))
+	var buf bytes.Buffer
+	require.NoError(t, tx.SerializeNoWitness(&buf))
+	return hex.EncodeToString(buf.Bytes())
+}
+
+func TestDogeSignTx(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/doge/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": "KzQJ9vR4JeoJicejXmdvjcoDmZHa665diNxt17o3KRw3Hvix5CA5"}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	ours := p2pkhScript(t, "D9CJPqih9zaKTTgpY1msoQRBUjDbEXNvtJ")
+	foreign := p2pkhScript(t, "DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L")
+	prevScripts := [][]byte{ours, foreign}
+	amounts := []int64{300_000_000, 200_000_000}
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/doge/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"tx": unsignedTx(t, 2, foreign, 450_000_000),
+		"prev_outputs": []interface{}{
+			map[string]interface{}{"script": hex.EncodeToString(ours), "amount": amounts[0]},
+			map[string]interface{}{"script": hex.EncodeToString(foreign), "amount": amounts[1]},
+		},
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, resp.Data["signed_inputs"])
+	assert.Equal(t, int64(50_000_000), resp.Data["fee"])
+
+	raw, err := hex.DecodeString(resp.Data["tx"].(string))
+	require.NoError(t, err)
+	var tx wire.MsgTx
+	require.NoError(t, tx.DeserializeNoWitness(bytes.NewReader(raw)))
+	assert.Equal(t, tx.TxHash().String(), resp.Data["txid"])
+	assert.Empty(t, tx.TxIn[1].SignatureScript)
+
+	// scriptSig проходит проверку скриптом выхода: подпись SIGHASH_ALL и сжатый ключ
+	pushes, err := txscript.PushedData(tx.TxIn[0].SignatureScript)
+	require.NoError(t, err)
+	require.Len(t, pushes, 2)
+	assert.Equal(t, byte(txscript.SigHashAll), pushes[0][len(pushes[0])-1])
+	assert.Len(t, pushes[1], 33)
+	vm, err := txscript.NewEngine(prevScripts[0], &tx, 0, txscript.StandardVerifyFlags, nil, nil, amounts[0], nil)
+	require.NoError(t, err)
+	require.NoError(t, vm.Execute())
+}
+
+func TestDogeSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/doge/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": "KzQJ9vR4JeoJicejXmdvjcoDmZHa665diNxt17o3KRw3Hvix5CA5"}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	ours := hex.EncodeToString(p2pkhScript(t, "D9CJPqih9zaKTTgpY1msoQRBUjDbEXNvtJ"))
+	foreign := p2pkhScript(t, "DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L")
+	tx := unsignedTx(t, 1, foreign, 100)
+
+	// десять выходов по MAX_MONEY: сумма в int64 переполнилась бы и стала отрицательной
+	large := wire.NewMsgTx(1)
+	large.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	for i := 0; i < 10; i++ {
+		large.AddTxOut(wire.NewTxOut(1e18, foreign))
+	}
+	var buf bytes.Buffer
+	require.NoError(t, large.SerializeNoWitness(&buf))
+	largeOutputs := hex.EncodeToString(buf.Bytes())
+
+	// девятнадцать входов по MAX_MONEY: переполненная сумма снова положительна
+	manyInputs := make([]interface{}, 19)
+	for i := range manyInputs {
+		manyInputs[i] = map[string]interface{}{"script": ours, "amount": int64(1e18)}
+	}
+
+	cases := map[string]map[string]interface{}{
+		"large outputs":    {"tx": largeOutputs, "prev_outputs": []interface{}{map[string]interface{}{"script": ours, "amount": 1}}},
+		"large inputs":     {"tx": unsignedTx(t, 19, foreign, 100), "prev_outputs": manyInputs},
+		"not hex":          {"tx": "zz", "prev_outputs": []interface{}{map[string]interface{}{"script": ours, "amount": 100}}},
+		"missing prevouts": {"tx": tx},
+		"prevout count":    {"tx": tx, "prev_outputs": []interface{}{}},
+		"missing amount":   {"tx": tx, "prev_outputs": []interface{}{map[string]interface{}{"script": ours}}},
+		"outputs exceed":   {"tx": tx, "prev_outputs": []interface{}{map[string]interface{}{"script": ours, "amount": 99}}},
+		"foreign inputs":   {"tx": tx, "prev_outputs": []interface{}{map[string]interface{}{"script": hex.EncodeToString(foreign), "amount": 100}}},
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/doge/svc/sign-tx")
+			req.Storage = storage
+			req.Data = data
+			_, err := b.HandleRequest(context.Background(), req)
+			require.Error(t, err)
+		})
+	}
+}
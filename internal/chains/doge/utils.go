@@ -2,13 +2,68 @@ package doge
 
 import (
 	"crypto/sha256"
+
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
 	"golang.org/x/crypto/ripemd160"
 )
 
-// DeriveAddress builds a P2PKH Dogecoin address (starts with "D")
-func DeriveAddress(pub *btcec.PublicKey) (string, error) {
+// hdPathFormat is the BIP-44 path for Dogecoin (coin type 3 on mainnet, 1 on testnet), %d is the address index
+const hdPathFormat = "m/44'/%d'/0'/0/%%d"
+
+// Dogecoin is not part of chaincfg, only the fields used for address and WIF encoding are filled in
+var (
+	mainNetParams = chaincfg.Params{
+		Name:             "mainnet",
+		PubKeyHashAddrID: 0x1E, // D...
+		ScriptHashAddrID: 0x16, // 9... / A...
+		PrivateKeyID:     0x9E, // Q... / 6...
+		HDCoinType:       3,
+	}
+	testNetParams = chaincfg.Params{
+		Name:             "testnet",
+		PubKeyHashAddrID: 0x71, // n...
+		ScriptHashAddrID: 0xC4, // 2...
+		PrivateKeyID:     0xF1,
+		HDCoinType:       1,
+	}
+)
+
+// networks — параметры сетей по значению настройки network
+var networks = map[string]*chaincfg.Params{
+	"mainnet": &mainNetParams,
+	"testnet": &testNetParams,
+}
+
+// networkParams возвращает параметры сети; пустое значение — mainnet
+func networkParams(network string) (*chaincfg.Params, error) {
+	return backend.LookupNetwork(networks, network)
+}
+
+// validateConfig проверяет config/doge: допустим только network
+func validateConfig(cfg *types.ChainConfig) error {
+	if err := backend.CheckConfigFields(cfg, "network"); err != nil {
+		return err
+	}
+	_, err := networkParams(cfg.Network)
+	return err
+}
+
+// isWIFForNet reports whether the WIF version byte matches the network. Mainnet also accepts
+// Bitcoin mainnet WIF (K.../L...), which is how keys were imported before networks were checked.
+func isWIFForNet(wif *btcutil.WIF, params *chaincfg.Params) bool {
+	if wif.IsForNet(params) {
+		return true
+	}
+	return params == &mainNetParams && wif.IsForNet(&chaincfg.MainNetParams)
+}
+
+// DeriveAddress builds a P2PKH Dogecoin address (starts with "D" on mainnet, "n" on testnet)
+func DeriveAddress(pub *btcec.PublicKey, params *chaincfg.Params) (string, error) {
 	// compressed pubkey 33 bytes
 	pubBytes := pub.SerializeCompressed()
 	// SHA256
@@ -17,8 +72,8 @@ func DeriveAddress(pub *btcec.PublicKey) (string, error) {
 	r := ripemd160.New()
 	r.Write(h1[:])
 	h160 := r.Sum(nil)
-	// version byte for Doge P2PKH is 0x1E
-	versioned := append([]byte{0x1E}, h160...)
+	// version byte of the network P2PKH (0x1E for mainnet)
+	versioned := append([]byte{params.PubKeyHashAddrID}, h160...)
 	// checksum = first 4 of double SHA256
 	c1 := sha256.Sum256(versioned)
 	c2 := sha256.Sum256(c1[:])
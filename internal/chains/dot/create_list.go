@@ -0,0 +1,179 @@
+package dot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// PathCrud registers CRUD operations for Substrate key-managers
+func PathCrud() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathCrud(config.Chain.DOT),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.CreateOperation: &framework.PathOperation{
+				Callback: createKeyManager,
+			},
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: backend.WrapperReadKeyManager(config.Chain.DOT),
+			},
+			logical.DeleteOperation: &framework.PathOperation{
+				Callback: backend.WrapperDeleteKeyManager(config.Chain.DOT),
+			},
+		},
+		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.DOT),
+		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
+		HelpDescription: backend.DefaultHelpDescriptionCreateList,
+		Fields:          createFields,
+	}
+}
+
+var createFields = backend.CrudFields(map[string]*framework.FieldSchema{
+	"key_type": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Account key type: sr25519 (default) or ed25519",
+	},
+	"network": {
+		Type:        framework.TypeString,
+		Description: "(Optional) SS58 network of the service: polkadot, kusama, westend or substrate. Defaults to config/dot, then polkadot. Fixed on the first key.",
+	},
+})
+
+// createKeyManager handles POST /key-managers/dot
+func createKeyManager(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	serviceName, ok := data.Get("name").(string)
+	if !ok || serviceName == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	// optional private key: 32-byte sr25519 mini secret or ed25519 seed, hex
+	privHex, ok := data.Get("private_key").(string)
+	if !ok {
+		return nil, fmt.Errorf("private_key must be a string")
+	}
+	privHex = strings.TrimSpace(privHex)
+	kt, err := parseKeyType(data.Get("key_type").(string))
+	if err != nil {
+		return nil, err
+	}
+	requestedNetwork := strings.TrimSpace(data.Get("network").(string))
+	if requestedNetwork != "" {
+		if _, err := getNetworkPrefix(requestedNetwork); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, err := backend.GetChainConfig(ctx, req.Storage, config.Chain.DOT)
+	if err != nil {
+		return nil, err
+	}
+	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.DOT, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		km = &types.KeyManager{ServiceName: serviceName}
+	}
+
+	// у Substrate нет сети "mainnet": умолчание — polkadot
+	mountDefault := cfg.Network
+	if mountDefault == "" {
+		mountDefault = networkPolkadot
+	}
+	network, err := backend.SetupNetwork(km, requestedNetwork, mountDefault)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := getNetworkPrefix(network)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive, decode or generate the 32-byte secret
+	var secret []byte
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privHex != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormat)
+		if kt == KeyTypeEd25519 {
+			secret, err = hd.DeriveSubstrateEd25519(km.Mnemonic, km.Passphrase, derivationPath)
+		} else {
+			secret, err = hd.DeriveSr25519(km.Mnemonic, km.Passphrase, derivationPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else if privHex != "" {
+		secret, err = hex.DecodeString(strings.TrimPrefix(privHex, "0x"))
+		if err != nil || len(secret) != 32 {
+			return nil, fmt.Errorf("invalid private key")
+		}
+	} else {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+	}
+	defer zero(secret)
+
+	pub, err := publicKey(secret, kt)
+	if err != nil {
+		return nil, err
+	}
+	address := EncodeSS58(pub, prefix)
+
+	kp := &types.KeyPair{
+		PrivateKey: hex.EncodeToString(secret),
+		PublicKey:  hex.EncodeToString(pub),
+		Address:    address,
+		KeyType:    kt,
+	}
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
+	}
+	km.KeyPairs = append(km.KeyPairs, kp)
+
+	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(config.Chain.DOT, serviceName), km)
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	respData := map[string]interface{}{
+		"service_name": serviceName,
+		"address":      address,
+		"public_key":   kp.PublicKey,
+		"key_type":     kt,
+		"network":      network,
+		"ss58_prefix":  prefix,
+	}
+	backend.AddHDResponseData(respData, kp, generatedMnemonic)
+	return &logical.Response{Data: respData}, nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
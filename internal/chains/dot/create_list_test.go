@@ -0,0 +1,135 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aliceSecret — sr25519 mini secret //Alice из dev phrase
+const aliceSecret = "0xe5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a"
+
+const devPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+func TestDotCreateAndListKeyManagers(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// Import //Alice: SS58 с префиксом 0 (polkadot по умолчанию)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": aliceSecret}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5", resp.Data["address"])
+	assert.Equal(t, "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d", resp.Data["public_key"])
+	assert.Equal(t, "sr25519", resp.Data["key_type"])
+	assert.Equal(t, "polkadot", resp.Data["network"])
+
+	// Generate an ed25519 key in the same service
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"key_type": "ed25519"}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^1[1-9A-HJ-NP-Za-km-z]{46,47}$`, resp.Data["address"])
+	assert.Equal(t, "ed25519", resp.Data["key_type"])
+
+	// сеть сервиса фиксирована
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"network": "kusama"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+
+	// Read
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/dot/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 2)
+}
+
+func TestDotCreateKeyManagers_Networks(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	for network, address := range map[string]string{
+		"kusama":    "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		"substrate": "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+	} {
+		req := logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/"+network)
+		req.Storage = storage
+		req.Data = map[string]interface{}{
+			"private_key": aliceSecret,
+			"network":     network,
+		}
+		resp, err := b.HandleRequest(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, address, resp.Data["address"])
+	}
+
+	// сеть по умолчанию берётся из config/dot
+	req := logical.TestRequest(t, logical.UpdateOperation, "config/dot")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"network": "westend"}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/westend")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": aliceSecret}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", resp.Data["address"])
+	assert.Equal(t, "westend", resp.Data["network"])
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "config/dot")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"network": "mainnet"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+func TestDotCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": devPhrase,
+		"network":  "substrate",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "//0", resp.Data["derivation_path"])
+	assert.Regexp(t, `^5[1-9A-HJ-NP-Za-km-z]{47}$`, resp.Data["address"])
+
+	// ed25519 ключ того же сервиса получает следующий индекс
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"key_type": "ed25519"}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "//1", resp.Data["derivation_path"])
+}
+
+func TestDotCreateKeyManagers_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	for _, data := range []map[string]interface{}{
+		{"private_key": "zz"},
+		{"private_key": "0102"},
+		{"key_type": "ecdsa"},
+		{"network": "mainnet"},
+	} {
+		req := logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/bad")
+		req.Storage = storage
+		req.Data = data
+		_, err := b.HandleRequest(context.Background(), req)
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,70 @@
+package dot
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func PathSign() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSign(config.Chain.DOT),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signPayloadHandler},
+		},
+		HelpSynopsis:    "Sign a Substrate signing payload with an sr25519 or ed25519 key.",
+		HelpDescription: "POST name, address, hash(hex signing payload; over 256 bytes it is Blake2-256 hashed first) → signature(hex, 64 bytes) and multi_signature(hex, MultiSignature variant || signature).",
+		Fields: backend.SignFields(map[string]*framework.FieldSchema{
+			"hash": {
+				Type:        framework.TypeString,
+				Description: "Hex SCALE-encoded signing payload (e.g. ExtrinsicPayload); payloads over 256 bytes are hashed with Blake2-256 before signing.",
+				Default:     "",
+			},
+		}),
+	}
+}
+
+func signPayloadHandler(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name, payloadHex, address, err := backend.GetSignParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+	payload, err := hex.DecodeString(strings.TrimPrefix(payloadHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload hex: %w", err)
+	}
+
+	kp, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.DOT)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing key for address %s: %w", address, err)
+	}
+	secret, err := hex.DecodeString(kp.PrivateKey)
+	if err != nil || len(secret) != 32 {
+		return nil, fmt.Errorf("stored private key is not valid")
+	}
+	defer zero(secret)
+
+	kt, err := parseKeyType(kp.KeyType)
+	if err != nil {
+		return nil, err
+	}
+	sig, hashed, err := signPayload(secret, payload, kt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature":       hex.EncodeToString(sig),
+			"multi_signature": hex.EncodeToString(append([]byte{multiSignatureVariants[kt]}, sig...)),
+			"key_type":        kt,
+			"payload_hashed":  hashed,
+		},
+	}, nil
+}
@@ -0,0 +1,100 @@
+package dot_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/oasisprotocol/curve25519-voi/primitives/sr25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestDotSignSr25519(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createDotAccount(t, b, storage, "sr25519")
+	pk, err := sr25519.NewPublicKeyFromBytes(pub)
+	require.NoError(t, err)
+	signingContext := sr25519.NewSigningContext([]byte("substrate"))
+
+	// короткий payload подписывается как есть
+	payload, _ := hex.DecodeString(strings.Repeat("ab", 100))
+	resp := signDot(t, b, storage, address, "0x"+hex.EncodeToString(payload))
+	assert.Equal(t, false, resp.Data["payload_hashed"])
+	sig := decodeSignature(t, resp.Data["signature"])
+	assert.True(t, pk.Verify(signingContext.NewTranscriptBytes(payload), sig))
+	assert.Equal(t, "01"+resp.Data["signature"].(string), resp.Data["multi_signature"])
+
+	// длиннее 256 байт — подписывается Blake2-256 от payload
+	payload, _ = hex.DecodeString(strings.Repeat("cd", 257))
+	resp = signDot(t, b, storage, address, hex.EncodeToString(payload))
+	assert.Equal(t, true, resp.Data["payload_hashed"])
+	hash := blake2b.Sum256(payload)
+	sig = decodeSignature(t, resp.Data["signature"])
+	assert.True(t, pk.Verify(signingContext.NewTranscriptBytes(hash[:]), sig))
+	assert.False(t, pk.Verify(signingContext.NewTranscriptBytes(payload), sig))
+}
+
+func TestDotSignEd25519(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createDotAccount(t, b, storage, "ed25519")
+
+	payload, _ := hex.DecodeString(strings.Repeat("ab", 256))
+	resp := signDot(t, b, storage, address, hex.EncodeToString(payload))
+	assert.Equal(t, false, resp.Data["payload_hashed"])
+	sig, _ := hex.DecodeString(resp.Data["signature"].(string))
+	assert.True(t, ed25519.Verify(pub, payload, sig))
+	assert.Equal(t, "00"+resp.Data["signature"].(string), resp.Data["multi_signature"])
+
+	payload = append(payload, 0x01)
+	resp = signDot(t, b, storage, address, hex.EncodeToString(payload))
+	assert.Equal(t, true, resp.Data["payload_hashed"])
+	hash := blake2b.Sum256(payload)
+	sig, _ = hex.DecodeString(resp.Data["signature"].(string))
+	assert.True(t, ed25519.Verify(pub, hash[:], sig))
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/dot/svc/sign")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"hash": "zz", "address": address}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+func createDotAccount(t *testing.T, b logical.Backend, storage logical.Storage, keyType string) (string, []byte) {
+	t.Helper()
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/dot/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"key_type": keyType}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pub, err := hex.DecodeString(resp.Data["public_key"].(string))
+	require.NoError(t, err)
+	return resp.Data["address"].(string), pub
+}
+
+func signDot(t *testing.T, b logical.Backend, storage logical.Storage, address, payload string) *logical.Response {
+	t.Helper()
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/dot/svc/sign")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"hash":    payload,
+		"address": address,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	return resp
+}
+
+func decodeSignature(t *testing.T, value interface{}) *sr25519.Signature {
+	t.Helper()
+	raw, err := hex.DecodeString(value.(string))
+	require.NoError(t, err)
+	sig, err := sr25519.NewSignatureFromBytes(raw)
+	require.NoError(t, err)
+	return sig
+}
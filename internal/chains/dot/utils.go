@@ -0,0 +1,135 @@
+package dot
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	"github.com/oasisprotocol/curve25519-voi/primitives/sr25519"
+	"golang.org/x/crypto/blake2b"
+)
+
+// hdPathFormat — жёсткий junction с индексом адреса, как mnemonic//0 в polkadot.js
+const hdPathFormat = "//%d"
+
+// Key types of a Substrate account
+const (
+	KeyTypeSr25519 = "sr25519"
+	KeyTypeEd25519 = "ed25519"
+)
+
+// MultiSignature variants of the signature in an extrinsic
+var multiSignatureVariants = map[string]byte{
+	KeyTypeEd25519: 0x00,
+	KeyTypeSr25519: 0x01,
+}
+
+// signingContext — контекст schnorrkel, которым Substrate подписывает sr25519
+var signingContext = []byte("substrate")
+
+// maxUnhashedPayload — payload длиннее 256 байт подписывается через Blake2-256 (правило ExtrinsicPayload)
+const maxUnhashedPayload = 256
+
+// networkPolkadot — сеть по умолчанию
+const networkPolkadot = "polkadot"
+
+// SS58 address prefixes of the supported networks
+var networks = map[string]uint16{
+	networkPolkadot: 0,
+	"kusama":        2,
+	"westend":       42,
+	"substrate":     42,
+}
+
+var ss58Prefix = []byte("SS58PRE")
+
+func getNetworkPrefix(network string) (uint16, error) {
+	prefix, ok := networks[network]
+	if !ok {
+		return 0, fmt.Errorf("unsupported network %q", network)
+	}
+	return prefix, nil
+}
+
+func parseKeyType(t string) (string, error) {
+	switch t {
+	case "", KeyTypeSr25519:
+		return KeyTypeSr25519, nil
+	case KeyTypeEd25519:
+		return KeyTypeEd25519, nil
+	}
+	return "", fmt.Errorf("unsupported key_type %q", t)
+}
+
+// validateConfig проверяет config/dot: допустима только network
+func validateConfig(cfg *types.ChainConfig) error {
+	if err := backend.CheckConfigFields(cfg, "network"); err != nil {
+		return err
+	}
+	if cfg.Network == "" {
+		return nil
+	}
+	_, err := getNetworkPrefix(cfg.Network)
+	return err
+}
+
+// publicKey возвращает 32-байтовый публичный ключ из mini secret (sr25519) или seed (ed25519)
+func publicKey(secret []byte, kt string) ([]byte, error) {
+	if kt == KeyTypeEd25519 {
+		return ed25519.NewKeyFromSeed(secret).Public().(ed25519.PublicKey), nil
+	}
+	msk, err := sr25519.NewMiniSecretKeyFromBytes(secret)
+	if err != nil {
+		return nil, err
+	}
+	return msk.ExpandEd25519().PublicKey().MarshalBinary()
+}
+
+// signPayload подписывает payload; длинный payload заменяется его Blake2-256
+func signPayload(secret, payload []byte, kt string) (sig []byte, hashed bool, err error) {
+	msg := payload
+	if len(msg) > maxUnhashedPayload {
+		h := blake2b.Sum256(msg)
+		msg, hashed = h[:], true
+	}
+
+	if kt == KeyTypeEd25519 {
+		return ed25519.Sign(ed25519.NewKeyFromSeed(secret), msg), hashed, nil
+	}
+	msk, err := sr25519.NewMiniSecretKeyFromBytes(secret)
+	if err != nil {
+		return nil, false, err
+	}
+	// как в sp_core: ExpandEd25519 и случайный nonce, подпись каждый раз разная
+	transcript := sr25519.NewSigningContext(signingContext).NewTranscriptBytes(msg)
+	signature, err := msk.ExpandEd25519().KeyPair().Sign(rand.Reader, transcript)
+	if err != nil {
+		return nil, false, err
+	}
+	sig, err = signature.MarshalBinary()
+	return sig, hashed, err
+}
+
+// EncodeSS58 encodes a 32-byte public key as an SS58 address with the network prefix.
+func EncodeSS58(pub []byte, prefix uint16) string {
+	var data []byte
+	if prefix < 64 {
+		data = []byte{byte(prefix)}
+	} else {
+		// двухбайтовый префикс: 01xxxxxx в первом байте
+		data = []byte{byte(prefix&0xfc)>>2 | 0x40, byte(prefix>>8) | byte(prefix&0x03)<<6}
+	}
+	data = append(data, pub...)
+	return base58.Encode(append(data, ss58Checksum(data)...))
+}
+
+// ss58Checksum — первые 2 байта Blake2b-512("SS58PRE" || префикс || ключ)
+func ss58Checksum(data []byte) []byte {
+	h, _ := blake2b.New512(nil)
+	h.Write(ss58Prefix)
+	h.Write(data)
+	return h.Sum(nil)[:2]
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
 	"github.com/dsshard/vault-crypto-adapters/internal/types"
 	"github.com/ethereum/go-ethereum/log"
 	"regexp"
@@ -17,21 +18,21 @@ import (
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
-func PathCrud() *framework.Path {
+func PathCrud(chain config.ChainType) *framework.Path {
 	return &framework.Path{
-		Pattern: config.CreatePathCrud(config.Chain.ETH),
+		Pattern: config.CreatePathCrud(chain),
 		Operations: map[logical.Operation]framework.OperationHandler{
 			logical.CreateOperation: &framework.PathOperation{
-				Callback: createKeyManager,
+				Callback: withChain(chain, createKeyManager),
 			},
 			logical.ReadOperation: &framework.PathOperation{
-				Callback: backend.WrapperReadKeyManager(config.Chain.ETH),
+				Callback: backend.WrapperReadKeyManager(chain),
 			},
 			logical.DeleteOperation: &framework.PathOperation{
-				Callback: backend.WrapperDeleteKeyManager(config.Chain.ETH),
+				Callback: backend.WrapperDeleteKeyManager(chain),
 			},
 		},
-		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.ETH),
+		ExistenceCheck:  backend.KeyManagerExistenceCheck(chain),
 		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
 		HelpDescription: backend.DefaultHelpDescriptionCreateList,
 		Fields:          backend.DefaultCrudOperations,
@@ -39,6 +40,7 @@ func PathCrud() *framework.Path {
 }
 
 func createKeyManager(
+	chain config.ChainType,
 	ctx context.Context,
 	req *logical.Request,
 	data *framework.FieldData,
@@ -53,7 +55,7 @@ func createKeyManager(
 		return nil, types.ErrInvalidType
 	}
 
-	keyManager, err := backend.RetrieveKeyManager(ctx, req, config.Chain.ETH, serviceName)
+	keyManager, err := backend.RetrieveKeyManager(ctx, req, chain, serviceName)
 	if err != nil {
 		return nil, err
 	}
@@ -64,10 +66,30 @@ func createKeyManager(
 		}
 	}
 
+	generatedMnemonic, err := backend.SetupMnemonic(keyManager, data)
+	if err != nil {
+		return nil, err
+	}
+
 	var privateKeyExport *ecdsa.PrivateKey
 	var privateKeyBytes []byte
+	var derivationPath string
+	var derivationIndex uint32
 
-	if privateKey != "" {
+	if keyManager.Mnemonic != "" {
+		if privateKey != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		derivationPath, derivationIndex = backend.NextDerivationPath(keyManager, hdPathFormat)
+		derived, err := hd.DeriveSecp256k1(keyManager.Mnemonic, keyManager.Passphrase, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		privateKeyExport, err = crypto.ToECDSA(derived)
+		if err != nil {
+			return nil, fmt.Errorf("error reconstructing derived private key, %w", err)
+		}
+	} else if privateKey != "" {
 		re := regexp.MustCompile("[0-9a-fA-F]{64}$")
 
 		key := re.FindString(privateKey)
@@ -102,21 +124,28 @@ func createKeyManager(
 		PublicKey:  common.Bytes2Hex(publicKeyBytes),
 		Address:    crypto.PubkeyToAddress(*publicKeyECDSA).Hex(),
 	}
+	if derivationPath != "" {
+		keyPair.DerivationPath = derivationPath
+		keyPair.DerivationIndex = &derivationIndex
+	}
 
 	keyManager.KeyPairs = append(keyManager.KeyPairs, keyPair)
 
-	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(config.Chain.ETH, serviceName), keyManager)
+	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(chain, serviceName), keyManager)
 	err = req.Storage.Put(ctx, entry)
 	if err != nil {
 		log.Error("Failed to save the new keyManager to storage", "error", err)
 		return nil, err
 	}
 
+	respData := map[string]interface{}{
+		"service_name": keyManager.ServiceName,
+		"address":      keyPair.Address,
+		"public_key":   keyPair.PublicKey,
+	}
+	backend.AddHDResponseData(respData, keyPair, generatedMnemonic)
+
 	return &logical.Response{
-		Data: map[string]interface{}{
-			"service_name": keyManager.ServiceName,
-			"address":      keyPair.Address,
-			"public_key":   keyPair.PublicKey,
-		},
+		Data: respData,
 	}, nil
 }
@@ -3,6 +3,7 @@ package eth_test
 import (
 	"context"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/dsshard/vault-crypto-adapters/internal/test"
@@ -81,3 +82,107 @@ func TestEthCreateAndListKeyManagers_InvalidPrivKey(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid private key")
 }
+
+func TestEthCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// 1) Import a well-known BIP-39 test mnemonic → m/44'/60'/0'/0/0
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/eth/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", resp.Data["address"])
+	assert.Equal(t, "m/44'/60'/0'/0/0", resp.Data["derivation_path"])
+	assert.NotContains(t, resp.Data, "mnemonic")
+
+	// 2) Next key is derived from the stored mnemonic with the next index
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/eth/hd")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0", resp.Data["address"])
+	assert.Equal(t, uint32(1), resp.Data["derivation_index"])
+
+	// 3) Raw private keys cannot be mixed into an HD key-manager
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/eth/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "4c0883a69102937a9280f1222f7c9b6645e1a3c7bf2e5b4cd0bd58d7f9f5d9b7",
+	}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+
+	// 4) Read returns derivation paths
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/eth/hd")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.Data["hd"])
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 2)
+	assert.Equal(t, "m/44'/60'/0'/0/1", rawPairs[1]["derivation_path"])
+}
+
+func TestEthCreateKeyManagers_GeneratedMnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/eth/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"hd": true}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	mnemonic, ok := resp.Data["mnemonic"].(string)
+	require.True(t, ok, "generated mnemonic must be returned once")
+	assert.Len(t, strings.Fields(mnemonic), 24)
+
+	// restoring the same mnemonic in another service gives the same address
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/eth/restored")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"mnemonic": mnemonic}
+	restored, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, resp.Data["address"], restored.Data["address"])
+}
+
+func TestEthCreateKeyManagers_InvalidMnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/eth/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+	}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid mnemonic")
+}
+
+func TestEvmChains_SeparateStorage(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	for _, chain := range []string{"polygon", "bsc", "arbitrum", "base", "avalanche"} {
+		req := logical.TestRequest(t, logical.CreateOperation, "key-managers/"+chain+"/svc")
+		req.Storage = storage
+		req.Data = map[string]interface{}{
+			"private_key": "4c0883a69102937a9280f1222f7c9b6645e1a3c7bf2e5b4cd0bd58d7f9f5d9b7",
+		}
+		resp, err := b.HandleRequest(context.Background(), req)
+		require.NoError(t, err, chain)
+		assert.Equal(t, "0x90Be49D363130726040fC1d05Ea29Fd090e0c8F0", resp.Data["address"], chain)
+
+		entry, err := storage.Get(context.Background(), "key-managers/"+chain+"/svc")
+		require.NoError(t, err)
+		require.NotNil(t, entry, chain)
+	}
+
+	// keys of an EVM chain are not visible under eth
+	req := logical.TestRequest(t, logical.ListOperation, "key-managers/eth")
+	req.Storage = storage
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Data["keys"])
+}
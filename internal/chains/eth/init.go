@@ -3,11 +3,25 @@ package eth
 import (
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
 )
 
 func init() {
-	backend.Register(config.Chain.ETH, backend.Endpoints{
-		Crud: PathCrud,
-		Sign: PathSign,
-	})
+	for _, chain := range config.EVMChains {
+		backend.Register(chain, Endpoints(chain))
+	}
+}
+
+// Endpoints собирает эндпоинты EVM-монеты: код общий с eth, путь и хранилище — свои
+func Endpoints(chain config.ChainType) backend.Endpoints {
+	return backend.Endpoints{
+		Crud: func() *framework.Path { return PathCrud(chain) },
+		Sign: func() *framework.Path { return PathSign(chain) },
+		Extra: []func() *framework.Path{
+			func() *framework.Path { return PathSignTx(chain) },
+			func() *framework.Path { return PathSignMessage(chain) },
+			func() *framework.Path { return PathSignTypedData(chain) },
+		},
+		ValidateConfig: validateConfig,
+	}
 }
@@ -12,12 +12,12 @@ import (
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
-func PathSign() *framework.Path {
+func PathSign(chain config.ChainType) *framework.Path {
 	return &framework.Path{
-		Pattern: config.CreatePathSign(config.Chain.ETH),
+		Pattern: config.CreatePathSign(chain),
 		Operations: map[logical.Operation]framework.OperationHandler{
 			logical.UpdateOperation: &framework.PathOperation{
-				Callback: sign,
+				Callback: withChain(chain, sign),
 			},
 		},
 		HelpSynopsis:    "Sign a provided transaction object.",
@@ -27,6 +27,7 @@ func PathSign() *framework.Path {
 }
 
 func sign(
+	chain config.ChainType,
 	ctx context.Context,
 	req *logical.Request,
 	data *framework.FieldData,
@@ -37,7 +38,7 @@ func sign(
 		return nil, fmt.Errorf("invalid request data: %w", err)
 	}
 
-	keyManager, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.ETH)
+	keyManager, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, chain)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving signing keyManager %s", address)
 	}
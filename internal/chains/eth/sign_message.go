@@ -0,0 +1,205 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+var signMessageFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"message": {
+		Type:        framework.TypeString,
+		Description: "Message to sign with the EIP-191 personal_sign prefix.",
+		Default:     "",
+	},
+	"encoding": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Message encoding: utf8 (default) or hex.",
+		Default:     "utf8",
+	},
+}
+
+var signTypedDataFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"typed_data": {
+		Type:        framework.TypeMap,
+		Description: "Full EIP-712 object: types, primaryType, domain and message (eth_signTypedData_v4).",
+	},
+}
+
+func PathSignMessage(chain config.ChainType) *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignMessage(chain),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: withChain(chain, signMessage),
+			},
+		},
+		HelpSynopsis:    "Sign a message with the EIP-191 personal_sign prefix.",
+		HelpDescription: "POST address + message (utf8 or hex) → signature (hex r||s||v, v = 27/28).",
+		Fields:          signMessageFields,
+	}
+}
+
+func PathSignTypedData(chain config.ChainType) *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTypedData(chain),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: withChain(chain, signTypedData),
+			},
+		},
+		HelpSynopsis:    "Sign EIP-712 typed data (eth_signTypedData_v4).",
+		HelpDescription: "POST address + typed_data → signature (hex r||s||v, v = 27/28), digest, decoded domain and primary type.",
+		Fields:          signTypedDataFields,
+	}
+}
+
+func signMessage(
+	chain config.ChainType,
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	message := data.Get("message").(string)
+	var payload []byte
+	switch data.Get("encoding").(string) {
+	case "utf8", "":
+		payload = []byte(message)
+	case "hex":
+		payload, err = hexutil.Decode(withHexPrefix(message))
+		if err != nil {
+			return nil, fmt.Errorf("invalid message hex: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", data.Get("encoding"))
+	}
+
+	// "\x19Ethereum Signed Message:\n" + len(message) + message
+	hash := accounts.TextHash(payload)
+	sig, err := signDigest(ctx, req, chain, name, address, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature": hexutil.Encode(sig),
+			"hash":      hexutil.Encode(hash),
+		},
+	}, nil
+}
+
+func signTypedData(
+	chain config.ChainType,
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	rawTypedData, ok := data.Get("typed_data").(map[string]interface{})
+	if !ok || len(rawTypedData) == 0 {
+		return nil, fmt.Errorf("typed_data must be a JSON object")
+	}
+
+	// переводим в apitypes через JSON, чтобы сохранить формат eth_signTypedData_v4
+	encoded, err := json.Marshal(rawTypedData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid typed_data: %w", err)
+	}
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(encoded, &typedData); err != nil {
+		return nil, fmt.Errorf("invalid typed_data: %w", err)
+	}
+	if typedData.PrimaryType == "" {
+		return nil, fmt.Errorf("invalid typed_data: primaryType is required")
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid typed_data: %w", err)
+	}
+
+	sig, err := signDigest(ctx, req, chain, name, address, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature":    hexutil.Encode(sig),
+			"hash":         hexutil.Encode(hash),
+			"primary_type": typedData.PrimaryType,
+			"domain":       domainSummary(typedData.Domain),
+		},
+	}, nil
+}
+
+// signDigest подписывает 32-байтовый digest и приводит v к 27/28, как ожидают кошельки
+func signDigest(ctx context.Context, req *logical.Request, chain config.ChainType, name, address string, digest []byte) ([]byte, error) {
+	keyPair, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, chain)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing keyManager %s", address)
+	}
+
+	privateKey, err := crypto.HexToECDSA(keyPair.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key from retrieved hex")
+	}
+	defer ZeroKey(privateKey)
+
+	sig, err := crypto.Sign(digest, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing digest: %w", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+func domainSummary(domain apitypes.TypedDataDomain) map[string]interface{} {
+	out := map[string]interface{}{}
+	if domain.Name != "" {
+		out["name"] = domain.Name
+	}
+	if domain.Version != "" {
+		out["version"] = domain.Version
+	}
+	if domain.ChainId != nil {
+		out["chain_id"] = (*big.Int)(domain.ChainId).String()
+	}
+	if domain.VerifyingContract != "" {
+		out["verifying_contract"] = domain.VerifyingContract
+	}
+	if domain.Salt != "" {
+		out["salt"] = domain.Salt
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package eth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthSignMessage(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	addr := createEthAccount(t, b, storage)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/eth/svc/sign-message")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address": addr,
+		"message": "Sign in to example.com",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	sig := hexutil.MustDecode(resp.Data["signature"].(string))
+	require.Len(t, sig, 65)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+
+	// recover signer from the EIP-191 digest
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte("Sign in to example.com")), sig)
+	require.NoError(t, err)
+	assert.Equal(t, addr, crypto.PubkeyToAddress(*pub).Hex())
+
+	// hex encoding signs the decoded bytes
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/eth/svc/sign-message")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":  addr,
+		"message":  "0xdeadbeef",
+		"encoding": "hex",
+	}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(accounts.TextHash([]byte{0xde, 0xad, 0xbe, 0xef})), resp.Data["hash"])
+}
+
+func TestEthSignTypedData(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// EIP-712 reference example, signer key = keccak256("cow")
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/eth/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "c85ef7d79691fe79573b1a7064c19c1a9819ebdbd1faaab1a8ec92344438aaf4",
+	}
+	account, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", account.Data["address"])
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/eth/svc/sign-typed-data")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address": account.Data["address"],
+		"typed_data": map[string]interface{}{
+			"types": map[string]interface{}{
+				"EIP712Domain": []interface{}{
+					map[string]interface{}{"name": "name", "type": "string"},
+					map[string]interface{}{"name": "version", "type": "string"},
+					map[string]interface{}{"name": "chainId", "type": "uint256"},
+					map[string]interface{}{"name": "verifyingContract", "type": "address"},
+				},
+				"Person": []interface{}{
+					map[string]interface{}{"name": "name", "type": "string"},
+					map[string]interface{}{"name": "wallet", "type": "address"},
+				},
+				"Mail": []interface{}{
+					map[string]interface{}{"name": "from", "type": "Person"},
+					map[string]interface{}{"name": "to", "type": "Person"},
+					map[string]interface{}{"name": "contents", "type": "string"},
+				},
+			},
+			"primaryType": "Mail",
+			"domain": map[string]interface{}{
+				"name":              "Ether Mail",
+				"version":           "1",
+				"chainId":           1,
+				"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+			},
+			"message": map[string]interface{}{
+				"from":     map[string]interface{}{"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+				"to":       map[string]interface{}{"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+				"contents": "Hello, Bob!",
+			},
+		},
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", resp.Data["hash"])
+	assert.Equal(t,
+		"0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c",
+		resp.Data["signature"])
+	assert.Equal(t, "Mail", resp.Data["primary_type"])
+	domain := resp.Data["domain"].(map[string]interface{})
+	assert.Equal(t, "Ether Mail", domain["name"])
+	assert.Equal(t, "1", domain["chain_id"])
+}
+
+func TestEthSignTypedData_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	addr := createEthAccount(t, b, storage)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/eth/svc/sign-typed-data")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":    addr,
+		"typed_data": map[string]interface{}{"message": map[string]interface{}{}},
+	}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid typed_data")
+}
@@ -0,0 +1,435 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/holiman/uint256"
+)
+
+// blobHashVersion — версия versioned hash для KZG-коммитментов (EIP-4844)
+const blobHashVersion = 0x01
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"chain_id": {
+		Type:        framework.TypeInt64,
+		Description: "EIP-155 chain id the transaction is valid for. Defaults to chain_id of config/<chain>, then to the id of the EVM chain (none for eth).",
+	},
+	"type": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) Transaction type: 0 legacy, 1 EIP-2930, 2 EIP-1559, 3 EIP-4844. Inferred from the fee fields when omitted.",
+	},
+	"nonce": {
+		Type:        framework.TypeInt64,
+		Description: "Account nonce.",
+	},
+	"to": {
+		Type:        framework.TypeString,
+		Description: "Recipient address. Required unless create_contract is set.",
+		Default:     "",
+	},
+	"create_contract": {
+		Type:        framework.TypeBool,
+		Description: "(Optional) Deploy a contract: the transaction has no recipient and data is the init code. Cannot be combined with to.",
+		Default:     false,
+	},
+	"value": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Amount of wei, decimal or 0x-hex.",
+		Default:     "0",
+	},
+	"data": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Hex call data.",
+		Default:     "",
+	},
+	"gas": {
+		Type:        framework.TypeInt64,
+		Description: "Gas limit.",
+	},
+	"gas_price": {
+		Type:        framework.TypeString,
+		Description: "Gas price in wei for legacy and EIP-2930 transactions.",
+		Default:     "",
+	},
+	"max_fee_per_gas": {
+		Type:        framework.TypeString,
+		Description: "Fee cap in wei for EIP-1559 and EIP-4844 transactions.",
+		Default:     "",
+	},
+	"max_priority_fee_per_gas": {
+		Type:        framework.TypeString,
+		Description: "Tip cap in wei for EIP-1559 and EIP-4844 transactions.",
+		Default:     "0",
+	},
+	"max_fee_per_blob_gas": {
+		Type:        framework.TypeString,
+		Description: "Blob fee cap in wei for EIP-4844 transactions.",
+		Default:     "",
+	},
+	"access_list": {
+		Type:        framework.TypeSlice,
+		Description: `(Optional) Access list: [{"address": "0x…", "storageKeys": ["0x…"]}].`,
+	},
+	"blob_hashes": {
+		Type:        framework.TypeCommaStringSlice,
+		Description: "Versioned blob hashes for EIP-4844 transactions.",
+	},
+}
+
+func PathSignTx(chain config.ChainType) *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(chain),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: withChain(chain, signTx),
+			},
+		},
+		HelpSynopsis:    "Build and sign an EVM transaction.",
+		HelpDescription: "POST address + transaction fields (legacy, EIP-2930, EIP-1559, EIP-4844) → raw signed transaction and its hash. Signed with EIP-155 replay protection for the chain id.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	chain config.ChainType,
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	chainID, err := resolveChainID(ctx, req, data, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := buildTransaction(chainID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPair, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, chain)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing keyManager %s", address)
+	}
+
+	privateKey, err := crypto.HexToECDSA(keyPair.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing private key from retrieved hex")
+	}
+	defer ZeroKey(privateKey)
+
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing transaction: %w", err)
+	}
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error encoding signed transaction: %w", err)
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"raw_tx":  hexutil.Encode(raw),
+			"tx_hash": signed.Hash().Hex(),
+			"type":    int(signed.Type()),
+			"from":    keyPair.Address,
+		},
+	}, nil
+}
+
+// resolveChainID берёт chain_id из запроса, иначе из config/<chain>, иначе chain id сети по умолчанию;
+// на EVM-сетях кроме eth chain_id закреплён за маунтом и не может указывать на другую сеть
+func resolveChainID(ctx context.Context, req *logical.Request, data *framework.FieldData, chain config.ChainType) (*big.Int, error) {
+	cfg, err := backend.GetChainConfig(ctx, req.Storage, chain)
+	if err != nil {
+		return nil, err
+	}
+	chainID := cfg.ChainID
+	if chainID == 0 {
+		chainID = config.EVMChainIDs[chain]
+	}
+	if raw, ok := data.GetOk("chain_id"); ok {
+		requested := raw.(int64)
+		if requested <= 0 {
+			return nil, errors.New("chain_id must be a positive integer")
+		}
+		if chain != config.Chain.ETH && uint64(requested) != chainID {
+			return nil, fmt.Errorf("chain_id %d does not match the %s chain id %d", requested, chain, chainID)
+		}
+		return big.NewInt(requested), nil
+	}
+	if chainID == 0 {
+		return nil, fmt.Errorf("chain_id is required: pass it or set it in config/%s", chain)
+	}
+	return new(big.Int).SetUint64(chainID), nil
+}
+
+// buildTransaction собирает типизированную транзакцию из полей запроса
+func buildTransaction(chainID *big.Int, data *framework.FieldData) (*types.Transaction, error) {
+	rawNonce, ok := data.GetOk("nonce")
+	if !ok {
+		return nil, errors.New("nonce is required")
+	}
+	nonce, ok := rawNonce.(int64)
+	if !ok || nonce < 0 {
+		return nil, errors.New("nonce must be a non-negative integer")
+	}
+	rawGas, ok := data.GetOk("gas")
+	if !ok {
+		return nil, errors.New("gas is required")
+	}
+	gas, ok := rawGas.(int64)
+	if !ok || gas <= 0 {
+		return nil, errors.New("gas must be a positive integer")
+	}
+
+	// пустой to не превращается молча в деплой контракта
+	toHex := strings.TrimSpace(data.Get("to").(string))
+	createContract := data.Get("create_contract").(bool)
+	switch {
+	case createContract && toHex != "":
+		return nil, errors.New("to cannot be combined with create_contract")
+	case !createContract && toHex == "":
+		return nil, errors.New("to is required, set create_contract=true to deploy a contract")
+	}
+	var to *common.Address
+	if toHex != "" {
+		if !common.IsHexAddress(toHex) {
+			return nil, fmt.Errorf("invalid to address %q", toHex)
+		}
+		addr := common.HexToAddress(toHex)
+		to = &addr
+	}
+
+	value, err := parseWei(data, "value")
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	var input []byte
+	if dataHex := strings.TrimSpace(data.Get("data").(string)); dataHex != "" {
+		input, err = hexutil.Decode(withHexPrefix(dataHex))
+		if err != nil {
+			return nil, fmt.Errorf("invalid data hex: %w", err)
+		}
+	}
+
+	accessList, err := parseAccessList(data.Get("access_list"))
+	if err != nil {
+		return nil, err
+	}
+	blobHashes, err := parseBlobHashes(data.Get("blob_hashes").([]string))
+	if err != nil {
+		return nil, err
+	}
+
+	txType, err := resolveTxType(data, accessList, blobHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch txType {
+	case types.LegacyTxType, types.AccessListTxType:
+		gasPrice, err := parseWei(data, "gas_price")
+		if err != nil {
+			return nil, err
+		}
+		if gasPrice == nil {
+			return nil, errors.New("gas_price is required for legacy and EIP-2930 transactions")
+		}
+		if txType == types.LegacyTxType {
+			if len(accessList) > 0 {
+				return nil, errors.New("legacy transactions cannot carry an access_list")
+			}
+			return types.NewTx(&types.LegacyTx{
+				Nonce:    uint64(nonce),
+				GasPrice: gasPrice,
+				Gas:      uint64(gas),
+				To:       to,
+				Value:    value,
+				Data:     input,
+			}), nil
+		}
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      uint64(nonce),
+			GasPrice:   gasPrice,
+			Gas:        uint64(gas),
+			To:         to,
+			Value:      value,
+			Data:       input,
+			AccessList: accessList,
+		}), nil
+
+	case types.DynamicFeeTxType:
+		feeCap, tipCap, err := parseFeeCaps(data)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      uint64(nonce),
+			GasTipCap:  tipCap,
+			GasFeeCap:  feeCap,
+			Gas:        uint64(gas),
+			To:         to,
+			Value:      value,
+			Data:       input,
+			AccessList: accessList,
+		}), nil
+
+	case types.BlobTxType:
+		if to == nil {
+			return nil, errors.New("blob transactions cannot create contracts, to is required")
+		}
+		if len(blobHashes) == 0 {
+			return nil, errors.New("blob_hashes are required for EIP-4844 transactions")
+		}
+		feeCap, tipCap, err := parseFeeCaps(data)
+		if err != nil {
+			return nil, err
+		}
+		blobFeeCap, err := parseWei(data, "max_fee_per_blob_gas")
+		if err != nil {
+			return nil, err
+		}
+		if blobFeeCap == nil {
+			return nil, errors.New("max_fee_per_blob_gas is required for EIP-4844 transactions")
+		}
+		return types.NewTx(&types.BlobTx{
+			ChainID:    uint256.MustFromBig(chainID),
+			Nonce:      uint64(nonce),
+			GasTipCap:  uint256.MustFromBig(tipCap),
+			GasFeeCap:  uint256.MustFromBig(feeCap),
+			Gas:        uint64(gas),
+			To:         *to,
+			Value:      uint256.MustFromBig(value),
+			Data:       input,
+			AccessList: accessList,
+			BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+			BlobHashes: blobHashes,
+		}), nil
+	}
+
+	return nil, fmt.Errorf("unsupported transaction type %d", txType)
+}
+
+// resolveTxType берёт явный type или выводит его из заполненных полей
+func resolveTxType(data *framework.FieldData, accessList types.AccessList, blobHashes []common.Hash) (uint8, error) {
+	if raw, ok := data.GetOk("type"); ok {
+		txType, _ := raw.(int)
+		if txType < types.LegacyTxType || txType > types.BlobTxType {
+			return 0, fmt.Errorf("unsupported transaction type %d", txType)
+		}
+		return uint8(txType), nil
+	}
+
+	switch {
+	case len(blobHashes) > 0:
+		return types.BlobTxType, nil
+	case data.Get("max_fee_per_gas").(string) != "":
+		return types.DynamicFeeTxType, nil
+	case len(accessList) > 0:
+		return types.AccessListTxType, nil
+	}
+	return types.LegacyTxType, nil
+}
+
+func parseFeeCaps(data *framework.FieldData) (feeCap, tipCap *big.Int, err error) {
+	feeCap, err = parseWei(data, "max_fee_per_gas")
+	if err != nil {
+		return nil, nil, err
+	}
+	if feeCap == nil {
+		return nil, nil, errors.New("max_fee_per_gas is required for EIP-1559 and EIP-4844 transactions")
+	}
+	tipCap, err = parseWei(data, "max_priority_fee_per_gas")
+	if err != nil {
+		return nil, nil, err
+	}
+	if tipCap == nil {
+		tipCap = new(big.Int)
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		return nil, nil, errors.New("max_priority_fee_per_gas cannot exceed max_fee_per_gas")
+	}
+	return feeCap, tipCap, nil
+}
+
+// parseWei читает 256-битное число (десятичное или 0x-hex); пустое поле → nil
+func parseWei(data *framework.FieldData, field string) (*big.Int, error) {
+	raw := strings.TrimSpace(data.Get(field).(string))
+	if raw == "" {
+		return nil, nil
+	}
+	v, ok := math.ParseBig256(raw)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s %q", field, raw)
+	}
+	return v, nil
+}
+
+func parseAccessList(raw interface{}) (types.AccessList, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	// access list приходит как JSON-массив объектов, формат совпадает с go-ethereum
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access_list: %w", err)
+	}
+	var accessList types.AccessList
+	if err := json.Unmarshal(encoded, &accessList); err != nil {
+		return nil, fmt.Errorf("invalid access_list: %w", err)
+	}
+	return accessList, nil
+}
+
+func parseBlobHashes(raw []string) ([]common.Hash, error) {
+	hashes := make([]common.Hash, 0, len(raw))
+	for _, h := range raw {
+		b, err := hexutil.Decode(withHexPrefix(h))
+		if err != nil || len(b) != common.HashLength {
+			return nil, fmt.Errorf("invalid blob hash %q", h)
+		}
+		if b[0] != blobHashVersion {
+			return nil, fmt.Errorf("blob hash %q has an unsupported version", h)
+		}
+		hashes = append(hashes, common.BytesToHash(b))
+	}
+	return hashes, nil
+}
+
+func withHexPrefix(s string) string {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s
+	}
+	return "0x" + s
+}
@@ -0,0 +1,274 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEthAccount(t *testing.T, b logical.Backend, storage logical.Storage) string {
+	t.Helper()
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/eth/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "4c0883a69102937a9280f1222f7c9b6645e1a3c7bf2e5b4cd0bd58d7f9f5d9b7",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	return resp.Data["address"].(string)
+}
+
+func decodeSignedTx(t *testing.T, resp *logical.Response) *types.Transaction {
+	t.Helper()
+	raw, err := hexutil.Decode(resp.Data["raw_tx"].(string))
+	require.NoError(t, err)
+	tx := new(types.Transaction)
+	require.NoError(t, tx.UnmarshalBinary(raw))
+	assert.Equal(t, tx.Hash().Hex(), resp.Data["tx_hash"])
+	return tx
+}
+
+func TestEthSignTx_Types(t *testing.T) {
+	cases := []struct {
+		name   string
+		fields map[string]interface{}
+		txType uint8
+	}{
+		{
+			name:   "legacy",
+			fields: map[string]interface{}{"gas_price": "20000000000"},
+			txType: types.LegacyTxType,
+		},
+		{
+			name: "eip2930",
+			fields: map[string]interface{}{
+				"gas_price": "0x4a817c800",
+				"access_list": []interface{}{
+					map[string]interface{}{
+						"address":     "0x000000000000000000000000000000000000dEaD",
+						"storageKeys": []interface{}{"0x0000000000000000000000000000000000000000000000000000000000000001"},
+					},
+				},
+			},
+			txType: types.AccessListTxType,
+		},
+		{
+			name:   "eip1559",
+			fields: map[string]interface{}{"max_fee_per_gas": "30000000000", "max_priority_fee_per_gas": "1000000000"},
+			txType: types.DynamicFeeTxType,
+		},
+		{
+			name: "eip4844",
+			fields: map[string]interface{}{
+				"max_fee_per_gas":      "30000000000",
+				"max_fee_per_blob_gas": "1",
+				"blob_hashes":          []interface{}{"0x01ab000000000000000000000000000000000000000000000000000000000000"},
+			},
+			txType: types.BlobTxType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, storage := test.NewTestBackend(t)
+			addr := createEthAccount(t, b, storage)
+
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/eth/svc/sign-tx")
+			req.Storage = storage
+			req.Data = map[string]interface{}{
+				"address":  addr,
+				"chain_id": 1,
+				"nonce":    7,
+				"to":       "0x3535353535353535353535353535353535353535",
+				"value":    "1000000000000000000",
+				"gas":      21000,
+				"data":     "0xdeadbeef",
+			}
+			for k, v := range tc.fields {
+				req.Data[k] = v
+			}
+			resp, err := b.HandleRequest(context.Background(), req)
+			require.NoError(t, err)
+
+			tx := decodeSignedTx(t, resp)
+			assert.Equal(t, tc.txType, tx.Type())
+			assert.Equal(t, uint64(7), tx.Nonce())
+			assert.Equal(t, big.NewInt(1), tx.ChainId())
+			assert.Equal(t, hexutil.MustDecode("0xdeadbeef"), tx.Data())
+
+			from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), tx)
+			require.NoError(t, err)
+			assert.Equal(t, addr, from.Hex())
+		})
+	}
+}
+
+func TestEthSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	addr := createEthAccount(t, b, storage)
+
+	cases := map[string]map[string]interface{}{
+		"missing chain id":      {"nonce": 0, "gas": 21000, "gas_price": "1"},
+		"missing nonce":         {"chain_id": 1, "gas": 21000, "gas_price": "1"},
+		"missing gas":           {"chain_id": 1, "nonce": 0, "gas_price": "1"},
+		"negative nonce":        {"chain_id": 1, "nonce": -1, "gas": 21000, "gas_price": "1"},
+		"to with create":        {"chain_id": 1, "nonce": 0, "gas": 21000, "gas_price": "1", "create_contract": true},
+		"missing gas price":     {"chain_id": 1, "nonce": 0, "gas": 21000},
+		"tip above fee cap":     {"chain_id": 1, "nonce": 0, "gas": 21000, "max_fee_per_gas": "1", "max_priority_fee_per_gas": "2"},
+		"blob without to":       {"chain_id": 1, "nonce": 0, "gas": 21000, "to": "", "max_fee_per_gas": "1", "max_fee_per_blob_gas": "1", "blob_hashes": []interface{}{"0x01ab000000000000000000000000000000000000000000000000000000000000"}},
+		"bad blob hash version": {"chain_id": 1, "nonce": 0, "gas": 21000, "max_fee_per_gas": "1", "max_fee_per_blob_gas": "1", "blob_hashes": []interface{}{"0x02ab000000000000000000000000000000000000000000000000000000000000"}},
+	}
+	for name, fields := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/eth/svc/sign-tx")
+			req.Storage = storage
+			req.Data = map[string]interface{}{
+				"address": addr,
+				"to":      "0x3535353535353535353535353535353535353535",
+			}
+			for k, v := range fields {
+				req.Data[k] = v
+			}
+			_, err := b.HandleRequest(context.Background(), req)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEthSignTx_CreateContract(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	addr := createEthAccount(t, b, storage)
+
+	signTx := func(fields map[string]interface{}) (*logical.Response, error) {
+		req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/eth/svc/sign-tx")
+		req.Storage = storage
+		req.Data = map[string]interface{}{
+			"address":   addr,
+			"chain_id":  1,
+			"nonce":     0,
+			"gas":       100000,
+			"gas_price": "1",
+			"data":      "0x6080",
+		}
+		for k, v := range fields {
+			req.Data[k] = v
+		}
+		return b.HandleRequest(context.Background(), req)
+	}
+
+	// без to и без create_contract транзакция не подписывается
+	_, err := signTx(nil)
+	require.ErrorContains(t, err, "to is required")
+	_, err = signTx(map[string]interface{}{"to": "  "})
+	require.ErrorContains(t, err, "to is required")
+
+	resp, err := signTx(map[string]interface{}{"create_contract": true})
+	require.NoError(t, err)
+	tx := decodeSignedTx(t, resp)
+	assert.Nil(t, tx.To())
+	assert.Equal(t, hexutil.MustDecode("0x6080"), tx.Data())
+}
+
+func TestEthSignTx_ChainIDFromConfig(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	addr := createEthAccount(t, b, storage)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "config/eth")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"chain_id": 11155111}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/eth/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":         addr,
+		"nonce":           0,
+		"to":              "0x3535353535353535353535353535353535353535",
+		"gas":             21000,
+		"max_fee_per_gas": "30000000000",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(11155111), decodeSignedTx(t, resp).ChainId())
+}
+
+func TestEvmSignTx_DefaultChainID(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/polygon/svc")
+	req.Storage = storage
+	account, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	addr := account.Data["address"].(string)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/polygon/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":   addr,
+		"nonce":     1,
+		"to":        "0x3535353535353535353535353535353535353535",
+		"gas":       21000,
+		"gas_price": "30000000000",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	// legacy transaction is EIP-155 protected for Polygon PoS (137)
+	tx := decodeSignedTx(t, resp)
+	assert.True(t, tx.Protected())
+	assert.Equal(t, big.NewInt(137), tx.ChainId())
+	from, err := types.Sender(types.NewEIP155Signer(big.NewInt(137)), tx)
+	require.NoError(t, err)
+	assert.Equal(t, addr, from.Hex())
+
+	// the key is not reachable through another chain
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/bsc/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"address": addr, "nonce": 1, "to": "0x3535353535353535353535353535353535353535", "gas": 21000, "gas_price": "1"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+func TestEvmSignTx_ChainIDPinned(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/polygon/svc")
+	req.Storage = storage
+	account, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	addr := account.Data["address"].(string)
+
+	signTx := func(chainID int) error {
+		req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/polygon/svc/sign-tx")
+		req.Storage = storage
+		req.Data = map[string]interface{}{
+			"address":   addr,
+			"chain_id":  chainID,
+			"nonce":     1,
+			"to":        "0x3535353535353535353535353535353535353535",
+			"gas":       21000,
+			"gas_price": "30000000000",
+		}
+		_, err := b.HandleRequest(context.Background(), req)
+		return err
+	}
+
+	// a polygon mount must not sign for Ethereum mainnet
+	require.Error(t, signTx(1))
+	require.NoError(t, signTx(137))
+
+	// config/polygon moves the mount to another network, e.g. Amoy
+	req = logical.TestRequest(t, logical.UpdateOperation, "config/polygon")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"chain_id": 80002}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	require.NoError(t, signTx(80002))
+	require.Error(t, signTx(137))
+}
@@ -1,9 +1,34 @@
 package eth
 
 import (
+	"context"
 	"crypto/ecdsa"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
 )
 
+// hdPathFormat — BIP-44 путь для Ethereum, %d заменяется индексом адреса
+const hdPathFormat = "m/44'/60'/0'/0/%d"
+
+// chainHandler — обработчик, общий для всех EVM-монет
+type chainHandler func(chain config.ChainType, ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error)
+
+// withChain привязывает обработчик к конкретной EVM-монете
+func withChain(chain config.ChainType, handler chainHandler) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		return handler(chain, ctx, req, data)
+	}
+}
+
+// validateConfig проверяет config/<evm chain>: допустим только chain_id
+func validateConfig(cfg *types.ChainConfig) error {
+	return backend.CheckConfigFields(cfg, "chain_id")
+}
+
 type Nonce struct {
 	ConfirmedNonce uint64
 	PendingNonce   uint64
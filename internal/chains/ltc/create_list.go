@@ -0,0 +1,119 @@
+package ltc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// PathCrud registers CRUD operations for Litecoin key-managers
+func PathCrud() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathCrud(config.Chain.LTC),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.CreateOperation: &framework.PathOperation{
+				Callback: createKeyManager,
+			},
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: backend.WrapperReadKeyManager(config.Chain.LTC),
+			},
+			logical.DeleteOperation: &framework.PathOperation{
+				Callback: backend.WrapperDeleteKeyManager(config.Chain.LTC),
+			},
+		},
+		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.LTC),
+		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
+		HelpDescription: backend.DefaultHelpDescriptionCreateList,
+		Fields:          createFields,
+	}
+}
+
+var createFields = backend.CrudFields(map[string]*framework.FieldSchema{
+	"network": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Network of the service: mainnet or testnet. Defaults to config/ltc, then mainnet. Fixed on the first key.",
+	},
+	"address_type": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Address type: segwit (P2WPKH, ltc1q...) or legacy (P2PKH, L...). Defaults to config/ltc, then segwit",
+	},
+})
+
+// createKeyManager handles POST /key-managers/ltc
+func createKeyManager(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	// service name
+	serviceName, ok := data.Get("name").(string)
+	if !ok || serviceName == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	// optional private key: WIF or hex
+	privInput, ok := data.Get("private_key").(string)
+	if !ok {
+		return nil, fmt.Errorf("private_key must be a string")
+	}
+	privInput = strings.TrimSpace(privInput)
+
+	cfg, err := backend.GetChainConfig(ctx, req.Storage, config.Chain.LTC)
+	if err != nil {
+		return nil, err
+	}
+	requestedType := strings.TrimSpace(data.Get("address_type").(string))
+	if requestedType == "" {
+		requestedType = cfg.AddressType
+	}
+	addrType, err := parseAddressType(requestedType)
+	if err != nil {
+		return nil, err
+	}
+	requestedNetwork := strings.TrimSpace(data.Get("network").(string))
+	if _, err := networkParams(requestedNetwork); err != nil {
+		return nil, err
+	}
+
+	// retrieve or init key-manager
+	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.LTC, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		km = &types.KeyManager{ServiceName: serviceName}
+	}
+
+	network, err := backend.SetupNetwork(km, requestedNetwork, cfg.Network)
+	if err != nil {
+		return nil, err
+	}
+	params, err := networkParams(network)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.CreateUTXOKeyPair(ctx, req, km, privInput, generatedMnemonic, backend.UTXOKeySpec{
+		Chain:      config.Chain.LTC,
+		Network:    network,
+		Params:     params,
+		PathFormat: hdPathFormat(addrType, params),
+		Address: func(pub *btcec.PublicKey) (string, error) {
+			return DeriveAddress(pub, addrType, params)
+		},
+		AddressType: addrType,
+	})
+}
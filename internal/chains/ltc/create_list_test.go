@@ -0,0 +1,150 @@
+package ltc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// WIF приватного ключа 1 (сжатый) с версией Litecoin mainnet 0xB0
+const ltcTestWIF = "T33ydQRKp4FCW5LCLLUB7deioUMoveiwekdwUwyfRDeGZm76aUjV"
+
+func TestLtcCreateAndListKeyManagers(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// Import specific privkey
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": ltcTestWIF,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9", resp.Data["address"])
+	assert.Equal(t, "segwit", resp.Data["address_type"])
+
+	// Same key as 32‑byte hex, legacy address
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key":  "0000000000000000000000000000000000000000000000000000000000000001",
+		"address_type": "legacy",
+	}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ", resp.Data["address"])
+
+	// Generate another key
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/svc")
+	req.Storage = storage
+	_, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	// Read
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/ltc/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 3)
+	assert.Equal(t, "legacy", rawPairs[1]["address_type"])
+	assert.Equal(t, "mainnet", resp.Data["network"])
+}
+
+func TestLtcCreateAndListKeyManagers2(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/svg")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	// litecoin mainnet P2WPKH address regexp
+	assert.Regexp(t, `^ltc1q[02-9ac-hj-np-z]{38}$`, resp.Data["address"])
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/svg")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address_type": "legacy",
+	}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^L[a-km-zA-HJ-NP-Z1-9]{33}$`, resp.Data["address"])
+}
+
+func TestLtcCreateAndListKeyManagers3(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	for name, data := range map[string]map[string]interface{}{
+		"invalid private key": {"private_key": "123"},
+		// Bitcoin mainnet WIF того же ключа
+		"bitcoin wif":  {"private_key": "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn"},
+		"address type": {"address_type": "taproot"},
+		"network":      {"network": "regtest"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/svc")
+			req.Storage = storage
+			req.Data = data
+			_, err := b.HandleRequest(context.Background(), req)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLtcCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "ltc1qjmxnz78nmc8nq77wuxh25n2es7rzm5c2rkk4wh", resp.Data["address"])
+	assert.Equal(t, "m/84'/2'/0'/0/0", resp.Data["derivation_path"])
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/hd-legacy")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"address_type": "legacy",
+	}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "LUWPbpM43E2p7ZSh8cyTBEkvpHmr3cB8Ez", resp.Data["address"])
+	assert.Equal(t, "m/44'/2'/0'/0/0", resp.Data["derivation_path"])
+}
+
+func TestLtcCreateKeyManagers_Testnet(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/staging")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"network":  "testnet",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/84'/1'/0'/0/0", resp.Data["derivation_path"])
+	assert.Equal(t, "testnet", resp.Data["network"])
+	assert.Regexp(t, `^tltc1q[02-9ac-hj-np-z]{38}$`, resp.Data["address"])
+
+	// Litecoin mainnet WIF is rejected on testnet
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/imported")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": ltcTestWIF,
+		"network":     "testnet",
+	}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package ltc
+
+import (
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+)
+
+func init() {
+	backend.Register(config.Chain.LTC, backend.Endpoints{
+		Crud:           PathCrud,
+		Sign:           PathSign,
+		ValidateConfig: validateConfig,
+	})
+}
@@ -0,0 +1,52 @@
+package ltc_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLtcSignHash(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// 1) Create a Litecoin key-manager for service "svc"
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ltc/svc")
+	req.Storage = storage
+	account, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	addr := account.Data["address"].(string)
+	pubBytes, err := hex.DecodeString(account.Data["public_key"].(string))
+	require.NoError(t, err)
+	pub, err := btcec.ParsePubKey(pubBytes)
+	require.NoError(t, err)
+
+	// 2) Sign a zero‐hash (32 bytes of 0x00)
+	hash := make([]byte, 32)
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/ltc/svc/sign")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"hash":    hex.EncodeToString(hash),
+		"address": addr,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	// 3) DER‐encoded ECDSA signature without a sighash byte, verifiable with the public key
+	sigBytes, err := hex.DecodeString(resp.Data["signature"].(string))
+	require.NoError(t, err)
+	sig, err := ecdsa.ParseDERSignature(sigBytes)
+	require.NoError(t, err)
+	assert.True(t, sig.Verify(hash, pub))
+
+	// 4) Invalid hash
+	req.Data["hash"] = "deadbeef"
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
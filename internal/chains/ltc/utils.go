@@ -0,0 +1,105 @@
+package ltc
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// Типы адресов Litecoin; хранятся в KeyPair.AddressType
+const (
+	AddressTypeLegacy = "legacy" // P2PKH, L...
+	AddressTypeSegwit = "segwit" // P2WPKH, ltc1q...
+)
+
+// hdPurposes — BIP-44/84 purpose по типу адреса
+var hdPurposes = map[string]uint32{
+	AddressTypeLegacy: 44,
+	AddressTypeSegwit: 84,
+}
+
+// Litecoin is not part of chaincfg, only the fields used for address and WIF encoding are filled in
+var (
+	mainNetParams = chaincfg.Params{
+		Name:             "mainnet",
+		PubKeyHashAddrID: 0x30, // L...
+		ScriptHashAddrID: 0x32, // M...
+		PrivateKeyID:     0xB0, // T... / 6...
+		Bech32HRPSegwit:  "ltc",
+		HDCoinType:       2,
+	}
+	testNetParams = chaincfg.Params{
+		Name:             "testnet",
+		PubKeyHashAddrID: 0x6F, // m... / n...
+		ScriptHashAddrID: 0x3A, // Q...
+		PrivateKeyID:     0xEF, // c...
+		Bech32HRPSegwit:  "tltc",
+		HDCoinType:       1,
+	}
+)
+
+// networks — параметры сетей по значению настройки network
+var networks = map[string]*chaincfg.Params{
+	"mainnet": &mainNetParams,
+	"testnet": &testNetParams,
+}
+
+// networkParams возвращает параметры сети; пустое значение — mainnet
+func networkParams(network string) (*chaincfg.Params, error) {
+	return backend.LookupNetwork(networks, network)
+}
+
+// validateConfig проверяет config/ltc: допустимы network и address_type
+func validateConfig(cfg *types.ChainConfig) error {
+	if err := backend.CheckConfigFields(cfg, "network", "address_type"); err != nil {
+		return err
+	}
+	if _, err := networkParams(cfg.Network); err != nil {
+		return err
+	}
+	_, err := parseAddressType(cfg.AddressType)
+	return err
+}
+
+// parseAddressType возвращает тип адреса; пустое значение — segwit
+func parseAddressType(addrType string) (string, error) {
+	if addrType == "" {
+		return AddressTypeSegwit, nil
+	}
+	if _, ok := hdPurposes[addrType]; !ok {
+		return "", fmt.Errorf("unsupported address_type %q", addrType)
+	}
+	return addrType, nil
+}
+
+// hdPathFormat возвращает BIP-44/84 путь для типа адреса и сети (coin type 2' или 1' для testnet),
+// %d заменяется индексом адреса
+func hdPathFormat(addrType string, params *chaincfg.Params) string {
+	return fmt.Sprintf("m/%d'/%d'/0'/0/%%d", hdPurposes[addrType], params.HDCoinType)
+}
+
+// DeriveAddress builds a P2WPKH (ltc1q...) or P2PKH (L...) Litecoin address for the network params
+func DeriveAddress(pub *btcec.PublicKey, addrType string, params *chaincfg.Params) (string, error) {
+	pubKeyHash := btcutil.Hash160(pub.SerializeCompressed())
+
+	var (
+		addr btcutil.Address
+		err  error
+	)
+	switch addrType {
+	case AddressTypeLegacy:
+		addr, err = btcutil.NewAddressPubKeyHash(pubKeyHash, params)
+	case AddressTypeSegwit:
+		addr, err = btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, params)
+	default:
+		return "", fmt.Errorf("unsupported address_type %q", addrType)
+	}
+	if err != nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
@@ -2,12 +2,19 @@ package chains
 
 import (
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/ada"
+	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/apt"
 	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/btc"
+	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/cosmos"
 	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/doge"
+	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/dot"
 	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/eth"
+	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/ltc"
 	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/sol"
+	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/sui"
 	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/ton"
 	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/trx"
+	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/xlm"
 	_ "github.com/dsshard/vault-crypto-adapters/internal/chains/xrp"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
 	"github.com/hashicorp/vault/sdk/framework"
@@ -24,13 +31,18 @@ func Paths() []*framework.Path {
 				ep.Crud(),
 				ep.Sign(),
 			)
+			for _, extra := range ep.Extra {
+				paths = append(paths, extra())
+			}
 		}
 	}
 
 	for _, chain := range config.AllChains {
 		paths = append(paths, backend.PathCrudList(chain))
 		paths = append(paths, backend.PathUpdateExternalData(chain))
+		paths = append(paths, backend.PathConfigChain(chain))
 	}
+	paths = append(paths, backend.PathConfig())
 
 	return paths
 }
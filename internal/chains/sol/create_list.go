@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
 	adaptersTypes "github.com/dsshard/vault-crypto-adapters/internal/types"
 	"github.com/mr-tron/base58"
 
@@ -60,9 +61,30 @@ func createKeyManager(
 		km = &adaptersTypes.KeyManager{ServiceName: serviceName}
 	}
 
-	// decode or generate
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive, decode or generate
 	var acct types.Account
-	if privateKey != "" {
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privateKey != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		// SLIP-0010 ed25519, account index in the third level as Phantom does
+		derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormat)
+		derived, err := hd.DeriveEd25519(km.Mnemonic, km.Passphrase, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		acct, err = types.AccountFromSeed(derived)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derived seed: %w", err)
+		}
+	} else if privateKey != "" {
 		// 1) Try hex‐encoded 32‐byte seed
 		hexSeed := strings.TrimPrefix(privateKey, "0x")
 		bs, errHex := hex.DecodeString(hexSeed)
@@ -95,6 +117,10 @@ func createKeyManager(
 		PublicKey:  acct.PublicKey.String(),
 		Address:    acct.PublicKey.String(),
 	}
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
+	}
 	km.KeyPairs = append(km.KeyPairs, kp)
 
 	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(config.Chain.SOL, serviceName), km)
@@ -102,11 +128,12 @@ func createKeyManager(
 		return nil, err
 	}
 
-	return &logical.Response{
-		Data: map[string]interface{}{
-			"service_name": serviceName,
-			"address":      kp.Address,
-			"public_key":   kp.PublicKey,
-		},
-	}, nil
+	respData := map[string]interface{}{
+		"service_name": serviceName,
+		"address":      kp.Address,
+		"public_key":   kp.PublicKey,
+	}
+	backend.AddHDResponseData(respData, kp, generatedMnemonic)
+
+	return &logical.Response{Data: respData}, nil
 }
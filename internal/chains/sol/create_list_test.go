@@ -81,3 +81,33 @@ func TestSolanaCreateAndListKeyManagers_InvalidPrivKey(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid private key")
 }
+
+func TestSolanaCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// Phantom derives m/44'/501'/0'/0' for the first account
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/sol/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk", resp.Data["address"])
+	assert.Equal(t, "m/44'/501'/0'/0'", resp.Data["derivation_path"])
+
+	// next account
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/sol/hd")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/501'/1'/0'", resp.Data["derivation_path"])
+
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/sol/hd")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 2)
+	assert.Equal(t, "m/44'/501'/0'/0'", rawPairs[0]["derivation_path"])
+}
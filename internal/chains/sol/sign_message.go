@@ -0,0 +1,169 @@
+package sol
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mr-tron/base58"
+)
+
+// offchainSigningDomain — префикс off-chain сообщений, с которого не может начинаться транзакция
+const offchainSigningDomain = "\xffsolana offchain"
+
+// форматы тела off-chain сообщения версии 0
+const (
+	offchainFormatRestrictedASCII = 0
+	offchainFormatLimitedUTF8     = 1
+	offchainFormatExtendedUTF8    = 2
+)
+
+// offchainHeaderLen — domain(16) + version(1) + format(1) + length(2)
+const offchainHeaderLen = len(offchainSigningDomain) + 4
+
+// offchainMaxLenLedger — тело, которое помещается в пакет (1232 байта) и подписывается Ledger; offchainMaxLen — предел u16
+const (
+	offchainMaxLenLedger = 1232 - offchainHeaderLen
+	offchainMaxLen       = 1<<16 - 1 - offchainHeaderLen
+)
+
+var offchainFormatNames = map[byte]string{
+	offchainFormatRestrictedASCII: "restricted-ascii",
+	offchainFormatLimitedUTF8:     "limited-utf8",
+	offchainFormatExtendedUTF8:    "extended-utf8",
+}
+
+var signMessageFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"message": {
+		Type:        framework.TypeString,
+		Description: "Message to sign in the off-chain message format (\\xffsolana offchain header).",
+		Default:     "",
+	},
+	"encoding": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Message encoding: utf8 (default) or hex.",
+		Default:     "utf8",
+	},
+}
+
+func PathSignMessage() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignMessage(config.Chain.SOL),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signMessage},
+		},
+		HelpSynopsis:    "Sign an off-chain message (Solana signMessage format).",
+		HelpDescription: "POST address + message (utf8 or hex) → signature (base58) over the message with the off-chain header (version 0, format, length).",
+		Fields:          signMessageFields,
+	}
+}
+
+func signMessage(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	message := []byte(data.Get("message").(string))
+	switch encoding := data.Get("encoding").(string); encoding {
+	case "utf8":
+	case "hex":
+		message, err = hex.DecodeString(strings.TrimPrefix(string(message), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex message: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", encoding)
+	}
+	// этим путём нельзя подписать транзакцию или её сообщение
+	if isTransactionLike(message) {
+		return nil, fmt.Errorf("message parses as a Solana transaction, use sign-tx")
+	}
+	serialized, format, err := serializeOffchainMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	acct, err := loadAccount(ctx, req, name, address)
+	if err != nil {
+		return nil, err
+	}
+	sig := acct.Sign(serialized)
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"signature":      base58.Encode(sig),
+			"signature_hex":  hex.EncodeToString(sig),
+			"signed_message": hex.EncodeToString(serialized),
+			"format":         offchainFormatNames[format],
+			"address":        address,
+		},
+	}, nil
+}
+
+// serializeOffchainMessage — domain || version(0) || format || len(u16 LE) || message
+func serializeOffchainMessage(message []byte) ([]byte, byte, error) {
+	if len(message) == 0 {
+		return nil, 0, fmt.Errorf("message must not be empty")
+	}
+	var format byte
+	switch {
+	case len(message) <= offchainMaxLenLedger && isPrintableASCII(message):
+		format = offchainFormatRestrictedASCII
+	case len(message) <= offchainMaxLenLedger && utf8.Valid(message):
+		format = offchainFormatLimitedUTF8
+	case len(message) <= offchainMaxLen && utf8.Valid(message):
+		format = offchainFormatExtendedUTF8
+	case !utf8.Valid(message):
+		return nil, 0, fmt.Errorf("message must be valid UTF-8")
+	default:
+		return nil, 0, fmt.Errorf("message must not exceed %d bytes", offchainMaxLen)
+	}
+
+	out := make([]byte, 0, offchainHeaderLen+len(message))
+	out = append(out, offchainSigningDomain...)
+	out = append(out, 0, format)
+	out = binary.LittleEndian.AppendUint16(out, uint16(len(message)))
+	return append(out, message...), format, nil
+}
+
+func isPrintableASCII(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// isTransactionLike — вход целиком разбирается как сообщение транзакции или подписанная транзакция
+func isTransactionLike(b []byte) bool {
+	if msg, err := deserializeMessage(b); err == nil {
+		if serialized, err := msg.Serialize(); err == nil && bytes.Equal(serialized, b) {
+			return true
+		}
+	}
+	if tx, err := deserializeTransaction(b); err == nil {
+		if serialized, err := tx.Serialize(); err == nil && bytes.Equal(serialized, b) {
+			return true
+		}
+	}
+	return false
+}
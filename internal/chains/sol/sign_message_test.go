@@ -0,0 +1,89 @@
+package sol_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/program/system"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolSignMessage(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	signer := createSolAccount(t, b, storage)
+
+	cases := []struct {
+		message string
+		format  string
+		header  string
+	}{
+		{"hello", "restricted-ascii", "ff736f6c616e61206f6666636861696e00000500"},
+		{"привет", "limited-utf8", "ff736f6c616e61206f6666636861696e00010c00"},
+		{strings.Repeat("a", 2000), "extended-utf8", "ff736f6c616e61206f6666636861696e0002d007"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.format, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/sol/svc/sign-message")
+			req.Storage = storage
+			req.Data = map[string]interface{}{
+				"address": signer.ToBase58(),
+				"message": tc.message,
+			}
+			resp, err := b.HandleRequest(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.format, resp.Data["format"])
+
+			signed, err := hex.DecodeString(resp.Data["signed_message"].(string))
+			require.NoError(t, err)
+			assert.Equal(t, tc.header+hex.EncodeToString([]byte(tc.message)), hex.EncodeToString(signed))
+
+			sig, err := base58.Decode(resp.Data["signature"].(string))
+			require.NoError(t, err)
+			assert.True(t, ed25519.Verify(signer.Bytes(), signed, sig))
+			assert.Equal(t, hex.EncodeToString(sig), resp.Data["signature_hex"])
+		})
+	}
+}
+
+func TestSolSignMessage_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	signer := createSolAccount(t, b, storage)
+
+	msg := types.NewMessage(types.NewMessageParam{
+		FeePayer:        signer,
+		RecentBlockhash: solTestBlockhash,
+		Instructions:    []types.Instruction{system.Transfer(system.TransferParam{From: signer, To: signer, Amount: 1})},
+	})
+	message, err := msg.Serialize()
+	require.NoError(t, err)
+	tx, err := base64.StdEncoding.DecodeString(serializeUnsigned(t, msg))
+	require.NoError(t, err)
+
+	cases := map[string]map[string]interface{}{
+		"transaction message": {"message": hex.EncodeToString(message), "encoding": "hex"},
+		"transaction":         {"message": hex.EncodeToString(tx), "encoding": "hex"},
+		"invalid utf8":        {"message": "ff00", "encoding": "hex"},
+		"empty":               {"message": ""},
+		"too long":            {"message": strings.Repeat("a", 1<<16)},
+		"unknown encoding":    {"message": "hello", "encoding": "base64"},
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/sol/svc/sign-message")
+			req.Storage = storage
+			req.Data = data
+			req.Data["address"] = signer.ToBase58()
+			_, err := b.HandleRequest(context.Background(), req)
+			require.Error(t, err)
+		})
+	}
+}
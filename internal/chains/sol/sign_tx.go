@@ -0,0 +1,195 @@
+package sol
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/bincode"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// индексы инструкций System Program и SPL Token, которые раскладываем в ответе
+const (
+	systemInstructionTransfer     = 2
+	tokenInstructionTransfer      = 3
+	tokenInstructionTransferCheck = 12
+)
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"transaction": {
+		Type:        framework.TypeString,
+		Description: "Base64 serialized transaction (legacy or v0) with empty or partial signatures.",
+	},
+}
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.SOL),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signTx},
+		},
+		HelpSynopsis:    "Sign a serialized Solana transaction.",
+		HelpDescription: "POST address, transaction (base64, legacy or v0) → transaction with the signature in the signer's slot, signature (base58) and decoded instructions.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data.Get("transaction").(string)))
+	if err != nil || len(raw) == 0 {
+		return nil, fmt.Errorf("transaction must be base64 encoded")
+	}
+	tx, err := deserializeTransaction(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+	if v := tx.Message.Version; v != types.MessageVersionLegacy && v != types.MessageVersionV0 {
+		return nil, fmt.Errorf("unsupported transaction version %s", v)
+	}
+	// подписываем ровно те байты сообщения, что прислали: повторная сериализация должна их воспроизвести
+	sigOffset := len(bincode.UintToVarLenBytes(uint64(len(tx.Signatures))))
+	messageOffset := sigOffset + len(tx.Signatures)*64
+	message, err := tx.Message.Serialize()
+	if err != nil || !bytes.Equal(message, raw[messageOffset:]) {
+		return nil, fmt.Errorf("invalid transaction: message is malformed or has trailing bytes")
+	}
+
+	acct, err := loadAccount(ctx, req, name, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// ключ сервиса должен быть среди первых NumRequireSignatures аккаунтов
+	signerIndex := -1
+	for i := 0; i < int(tx.Message.Header.NumRequireSignatures); i++ {
+		if tx.Message.Accounts[i] == acct.PublicKey {
+			signerIndex = i
+			break
+		}
+	}
+	if signerIndex < 0 {
+		return nil, fmt.Errorf("%s is not a required signer of the transaction", address)
+	}
+
+	sig := acct.Sign(message)
+	signed := append([]byte(nil), raw...)
+	copy(signed[sigOffset+signerIndex*64:], sig)
+	tx.Signatures[signerIndex] = sig
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"transaction":      base64.StdEncoding.EncodeToString(signed),
+			"signature":        base58.Encode(sig),
+			"signer_index":     signerIndex,
+			"fully_signed":     fullySigned(tx.Signatures),
+			"version":          string(tx.Message.Version),
+			"fee_payer":        tx.Message.Accounts[0].ToBase58(),
+			"recent_blockhash": tx.Message.RecentBlockHash,
+			"instructions":     decodeInstructions(tx.Message),
+		},
+	}, nil
+}
+
+func fullySigned(signatures []types.Signature) bool {
+	empty := make([]byte, 64)
+	for _, sig := range signatures {
+		if bytes.Equal(sig, empty) {
+			return false
+		}
+	}
+	return true
+}
+
+// messageAccounts — адреса по индексам инструкций; для v0 за статическими ключами идут адреса из lookup-таблиц,
+// сначала все writable, затем все readonly, — их значения вне цепи неизвестны, показываем таблицу и индекс
+func messageAccounts(m types.Message) []string {
+	accounts := make([]string, 0, len(m.Accounts))
+	for _, key := range m.Accounts {
+		accounts = append(accounts, key.ToBase58())
+	}
+	for _, table := range m.AddressLookupTables {
+		for _, idx := range table.WritableIndexes {
+			accounts = append(accounts, fmt.Sprintf("%s[%d]", table.AccountKey.ToBase58(), idx))
+		}
+	}
+	for _, table := range m.AddressLookupTables {
+		for _, idx := range table.ReadonlyIndexes {
+			accounts = append(accounts, fmt.Sprintf("%s[%d]", table.AccountKey.ToBase58(), idx))
+		}
+	}
+	return accounts
+}
+
+// decodeInstructions раскладывает System transfer и SPL Token transfer / transferChecked, остальное — program_id и data
+func decodeInstructions(m types.Message) []map[string]interface{} {
+	accounts := messageAccounts(m)
+	account := func(idx int) string {
+		if idx < 0 || idx >= len(accounts) {
+			return fmt.Sprintf("#%d", idx)
+		}
+		return accounts[idx]
+	}
+
+	decoded := make([]map[string]interface{}, 0, len(m.Instructions))
+	for _, ins := range m.Instructions {
+		programID := account(ins.ProgramIDIndex)
+		item := map[string]interface{}{
+			"program_id": programID,
+			"type":       "unknown",
+			"data":       hex.EncodeToString(ins.Data),
+		}
+		switch programID {
+		case common.SystemProgramID.ToBase58():
+			if len(ins.Data) == 12 && binary.LittleEndian.Uint32(ins.Data) == systemInstructionTransfer && len(ins.Accounts) >= 2 {
+				item["type"] = "system_transfer"
+				item["from"] = account(ins.Accounts[0])
+				item["to"] = account(ins.Accounts[1])
+				item["lamports"] = binary.LittleEndian.Uint64(ins.Data[4:])
+			}
+		case common.TokenProgramID.ToBase58(), common.Token2022ProgramID.ToBase58():
+			switch {
+			case len(ins.Data) == 9 && ins.Data[0] == tokenInstructionTransfer && len(ins.Accounts) >= 3:
+				item["type"] = "spl_transfer"
+				item["source"] = account(ins.Accounts[0])
+				item["destination"] = account(ins.Accounts[1])
+				item["owner"] = account(ins.Accounts[2])
+				item["amount"] = binary.LittleEndian.Uint64(ins.Data[1:])
+			case len(ins.Data) == 10 && ins.Data[0] == tokenInstructionTransferCheck && len(ins.Accounts) >= 4:
+				item["type"] = "spl_transfer_checked"
+				item["source"] = account(ins.Accounts[0])
+				item["mint"] = account(ins.Accounts[1])
+				item["destination"] = account(ins.Accounts[2])
+				item["owner"] = account(ins.Accounts[3])
+				item["amount"] = binary.LittleEndian.Uint64(ins.Data[1:])
+				item["decimals"] = ins.Data[9]
+			}
+		}
+		decoded = append(decoded, item)
+	}
+	return decoded
+}
@@ -0,0 +1,180 @@
+package sol_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/system"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const solTestBlockhash = "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N"
+
+func createSolAccount(t *testing.T, b logical.Backend, storage logical.Storage) common.PublicKey {
+	t.Helper()
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/sol/svc")
+	req.Storage = storage
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	return common.PublicKeyFromString(resp.Data["address"].(string))
+}
+
+func serializeUnsigned(t *testing.T, msg types.Message) string {
+	t.Helper()
+	tx, err := types.NewTransaction(types.NewTransactionParam{Message: msg})
+	require.NoError(t, err)
+	raw, err := tx.Serialize()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func signSolTx(t *testing.T, b logical.Backend, storage logical.Storage, signer common.PublicKey, encoded string) (*logical.Response, error) {
+	t.Helper()
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/sol/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":     signer.ToBase58(),
+		"transaction": encoded,
+	}
+	return b.HandleRequest(context.Background(), req)
+}
+
+func TestSolSignTx_Legacy(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	signer := createSolAccount(t, b, storage)
+	to := types.NewAccount().PublicKey
+	source := types.NewAccount().PublicKey
+	destination := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+
+	msg := types.NewMessage(types.NewMessageParam{
+		FeePayer:        signer,
+		RecentBlockhash: solTestBlockhash,
+		Instructions: []types.Instruction{
+			system.Transfer(system.TransferParam{From: signer, To: to, Amount: 1_000_000}),
+			token.TransferChecked(token.TransferCheckedParam{
+				From: source, To: destination, Mint: mint, Auth: signer, Amount: 2500, Decimals: 6,
+			}),
+			token.Transfer(token.TransferParam{From: source, To: destination, Auth: signer, Amount: 7}),
+		},
+	})
+	resp, err := signSolTx(t, b, storage, signer, serializeUnsigned(t, msg))
+	require.NoError(t, err)
+
+	raw, err := base64.StdEncoding.DecodeString(resp.Data["transaction"].(string))
+	require.NoError(t, err)
+	tx, err := types.TransactionDeserialize(raw)
+	require.NoError(t, err)
+	message, err := tx.Message.Serialize()
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(signer.Bytes(), message, tx.Signatures[0]))
+	assert.Equal(t, base58.Encode(tx.Signatures[0]), resp.Data["signature"])
+	assert.Equal(t, true, resp.Data["fully_signed"])
+	assert.Equal(t, "legacy", resp.Data["version"])
+
+	instructions := resp.Data["instructions"].([]map[string]interface{})
+	require.Len(t, instructions, 3)
+	assert.Equal(t, "system_transfer", instructions[0]["type"])
+	assert.Equal(t, signer.ToBase58(), instructions[0]["from"])
+	assert.Equal(t, to.ToBase58(), instructions[0]["to"])
+	assert.Equal(t, uint64(1_000_000), instructions[0]["lamports"])
+	assert.Equal(t, "spl_transfer_checked", instructions[1]["type"])
+	assert.Equal(t, mint.ToBase58(), instructions[1]["mint"])
+	assert.Equal(t, uint64(2500), instructions[1]["amount"])
+	assert.Equal(t, uint8(6), instructions[1]["decimals"])
+	assert.Equal(t, "spl_transfer", instructions[2]["type"])
+	assert.Equal(t, destination.ToBase58(), instructions[2]["destination"])
+	assert.Equal(t, uint64(7), instructions[2]["amount"])
+}
+
+func TestSolSignTx_SecondSignerV0(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	signer := createSolAccount(t, b, storage)
+	feePayer := types.NewAccount()
+	table := types.AddressLookupTableAccount{
+		Key:       types.NewAccount().PublicKey,
+		Addresses: []common.PublicKey{types.NewAccount().PublicKey},
+	}
+
+	// получатель берётся из lookup-таблицы, ключ сервиса — второй подписант
+	msg := types.NewMessage(types.NewMessageParam{
+		FeePayer:                   feePayer.PublicKey,
+		RecentBlockhash:            solTestBlockhash,
+		AddressLookupTableAccounts: []types.AddressLookupTableAccount{table},
+		Instructions: []types.Instruction{
+			system.Transfer(system.TransferParam{From: signer, To: table.Addresses[0], Amount: 5}),
+		},
+	})
+	require.Equal(t, types.MessageVersion(types.MessageVersionV0), msg.Version)
+	resp, err := signSolTx(t, b, storage, signer, serializeUnsigned(t, msg))
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Data["signer_index"])
+	assert.Equal(t, false, resp.Data["fully_signed"])
+	assert.Equal(t, "v0", resp.Data["version"])
+	assert.Equal(t, feePayer.PublicKey.ToBase58(), resp.Data["fee_payer"])
+
+	instructions := resp.Data["instructions"].([]map[string]interface{})
+	require.Len(t, instructions, 1)
+	assert.Equal(t, table.Key.ToBase58()+"[0]", instructions[0]["to"])
+
+	// подпись плательщика дополняет транзакцию до полностью подписанной
+	raw, err := base64.StdEncoding.DecodeString(resp.Data["transaction"].(string))
+	require.NoError(t, err)
+	tx, err := types.TransactionDeserialize(raw)
+	require.NoError(t, err)
+	message, err := tx.Message.Serialize()
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(signer.Bytes(), message, tx.Signatures[1]))
+	require.NoError(t, tx.AddSignature(feePayer.Sign(message)))
+	_, err = tx.Serialize()
+	require.NoError(t, err)
+}
+
+func TestSolSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	signer := createSolAccount(t, b, storage)
+	other := types.NewAccount().PublicKey
+
+	notSigner := types.NewMessage(types.NewMessageParam{
+		FeePayer:        other,
+		RecentBlockhash: solTestBlockhash,
+		Instructions:    []types.Instruction{system.Transfer(system.TransferParam{From: other, To: signer, Amount: 1})},
+	})
+	valid := types.NewMessage(types.NewMessageParam{
+		FeePayer:        signer,
+		RecentBlockhash: solTestBlockhash,
+		Instructions:    []types.Instruction{system.Transfer(system.TransferParam{From: signer, To: other, Amount: 1})},
+	})
+	trailing, err := base64.StdEncoding.DecodeString(serializeUnsigned(t, valid))
+	require.NoError(t, err)
+
+	// одна подпись, заголовок требует подписанта, но аккаунтов нет
+	noAccounts := append([]byte{1}, make([]byte, 64)...)
+	noAccounts = append(noAccounts, 1, 0, 0, 0)
+	noAccounts = append(noAccounts, make([]byte, 32)...)
+	noAccounts = append(noAccounts, 0)
+
+	for name, encoded := range map[string]string{
+		"not a signer":   serializeUnsigned(t, notSigner),
+		"not base64":     "%%%",
+		"empty":          "",
+		"garbage":        base64.StdEncoding.EncodeToString([]byte{1, 2, 3}),
+		"trailing bytes": base64.StdEncoding.EncodeToString(append(trailing, 0)),
+		"no accounts":    base64.StdEncoding.EncodeToString(noAccounts),
+		"truncated":      base64.StdEncoding.EncodeToString(trailing[:len(trailing)-10]),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := signSolTx(t, b, storage, signer, encoded)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package sol
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// hdPathFormat — SLIP-0010 путь Phantom для Solana, %d заменяется индексом аккаунта
+const hdPathFormat = "m/44'/501'/%d'/0'"
+
+// loadAccount достаёт ключ сервиса по адресу
+func loadAccount(ctx context.Context, req *logical.Request, name, address string) (types.Account, error) {
+	keyManager, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.SOL)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("error retrieving signing keyManager %s", address)
+	}
+	seed, err := hex.DecodeString(keyManager.PrivateKey)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("invalid hex seed: %w", err)
+	}
+	acct, err := types.AccountFromSeed(seed)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("AccountFromSeed: %w", err)
+	}
+	return acct, nil
+}
+
+// deserializeTransaction — TransactionDeserialize без паники на обрезанных данных
+func deserializeTransaction(raw []byte) (tx types.Transaction, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed transaction")
+		}
+	}()
+	tx, err = types.TransactionDeserialize(raw)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if int(tx.Message.Header.NumRequireSignatures) > len(tx.Message.Accounts) {
+		return types.Transaction{}, fmt.Errorf("more required signatures than accounts")
+	}
+	return tx, nil
+}
+
+// deserializeMessage — MessageDeserialize без паники на обрезанных данных
+func deserializeMessage(raw []byte) (msg types.Message, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed message")
+		}
+	}()
+	return types.MessageDeserialize(raw)
+}
@@ -0,0 +1,75 @@
+package sui_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// мнемоника и адрес m/44'/784'/0'/0'/0' из тестов Sui TS SDK
+const (
+	testMnemonic = "film crazy soon outside stand loop subway crumble thrive popular green nuclear struggle pistol arm wife phrase warfare march wheat nephew ask sunny firm"
+	testAddress  = "0xa2d14fad60c56049ecf75246a481934691214ce413e6a8ae2fe6834c173a6133"
+	testSeed     = "dd09307a43ba6dc186b5709e4ca51f4fc71911c143f7ceffcf8c60e6b03bc8fa"
+	// тот же seed в формате sui keytool export
+	testPrivKey = "suiprivkey1qrwsjvr6gwaxmsvxk4cfun99ra8uwxg3c9pl0nhle7xxpe4s80y05ctazer"
+)
+
+func TestSuiCreateAndListKeyManagers(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	for _, privateKey := range []string{testSeed, testPrivKey} {
+		req := logical.TestRequest(t, logical.CreateOperation, "key-managers/sui/svc")
+		req.Storage = storage
+		req.Data = map[string]interface{}{"private_key": privateKey}
+		resp, err := b.HandleRequest(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, testAddress, resp.Data["address"])
+	}
+
+	// Generate another key
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/sui/svc")
+	req.Storage = storage
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^0x[0-9a-f]{64}$`, resp.Data["address"])
+
+	// Read
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/sui/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 3)
+
+	for _, bad := range []string{
+		"zz",
+		"0102",
+		// secp256k1 (флаг 0x01) не поддерживается
+		"suiprivkey1q8wsjvr6gwaxmsvxk4cfun99ra8uwxg3c9pl0nhle7xxpe4s80y05z5ga65",
+		// испорченная контрольная сумма
+		"suiprivkey1qrwsjvr6gwaxmsvxk4cfun99ra8uwxg3c9pl0nhle7xxpe4s80y05ctazeq",
+	} {
+		req = logical.TestRequest(t, logical.CreateOperation, "key-managers/sui/bad")
+		req.Storage = storage
+		req.Data = map[string]interface{}{"private_key": bad}
+		_, err = b.HandleRequest(context.Background(), req)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestSuiCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/sui/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"mnemonic": testMnemonic}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, testAddress, resp.Data["address"])
+	assert.Equal(t, "m/44'/784'/0'/0'/0'", resp.Data["derivation_path"])
+}
@@ -0,0 +1,42 @@
+package sui_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuiSign(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createSuiAccount(t, b, storage)
+
+	msg := []byte{0x01, 0x02, 0x03}
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/sui/svc/sign")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"hash":    hex.EncodeToString(msg),
+		"address": address,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	sig, _ := hex.DecodeString(resp.Data["signature"].(string))
+	assert.True(t, ed25519.Verify(pub, msg, sig))
+}
+
+func createSuiAccount(t *testing.T, b logical.Backend, storage logical.Storage) (string, ed25519.PublicKey) {
+	t.Helper()
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/sui/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": testSeed}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pub, err := hex.DecodeString(resp.Data["public_key"].(string))
+	require.NoError(t, err)
+	return resp.Data["address"].(string), pub
+}
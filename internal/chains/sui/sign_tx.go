@@ -0,0 +1,111 @@
+package sui
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// transactionIntent — Intent { scope: TransactionData, version: V0, app_id: Sui }
+var transactionIntent = []byte{0x00, 0x00, 0x00}
+
+// transactionDataV1 — единственный вариант enum TransactionData
+const transactionDataV1 = 0x00
+
+// digestSalt — префикс digest транзакции (имя типа, как в sui-types)
+const digestSalt = "TransactionData::"
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"tx_bytes": {
+		Type:        framework.TypeString,
+		Description: "Base64 BCS-serialized TransactionData, as built by the Sui SDK or sui client --serialize-unsigned-transaction.",
+		Default:     "",
+	},
+}
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.SUI),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signTx},
+		},
+		HelpSynopsis:    "Sign Sui TransactionData.",
+		HelpDescription: "POST address, tx_bytes(base64 BCS TransactionData) → signature (base64 flag || signature || public key) over Blake2b-256 of the intent message, and the transaction digest.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+	input := strings.TrimSpace(data.Get("tx_bytes").(string))
+	if input == "" {
+		return nil, fmt.Errorf("tx_bytes is required")
+	}
+	txBytes, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return nil, fmt.Errorf("tx_bytes must be base64 encoded")
+	}
+	tx, err := decodeTransactionData(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tx_bytes: %w", err)
+	}
+	// аккаунт должен быть отправителем или спонсором газа (sponsored transaction подписывают оба)
+	if tx.Sender != address && tx.GasOwner != address {
+		return nil, fmt.Errorf("transaction sender %s is not %s", tx.Sender, address)
+	}
+
+	priv, err := loadKey(ctx, req, name, address)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(priv)
+	pub := priv.Public().(ed25519.PublicKey)
+
+	// подписывается Blake2b-256(intent || tx_bytes)
+	intentMessage := append(append([]byte{}, transactionIntent...), txBytes...)
+	digest := blake2b.Sum256(intentMessage)
+	sig := ed25519.Sign(priv, digest[:])
+
+	serialized := append([]byte{ed25519Flag}, sig...)
+	serialized = append(serialized, pub...)
+
+	txDigest := blake2b.Sum256(append([]byte(digestSalt), txBytes...))
+
+	respData := map[string]interface{}{
+		"address":    address,
+		"public_key": hex.EncodeToString(pub),
+		"signature":  base64.StdEncoding.EncodeToString(serialized),
+		"digest":     base58.Encode(txDigest[:]),
+		"sender":     tx.Sender,
+		"commands":   tx.Commands,
+		"gas_owner":  tx.GasOwner,
+		"gas_price":  tx.GasPrice,
+		"gas_budget": tx.GasBudget,
+	}
+	if tx.Expiration != nil {
+		respData["expiration_epoch"] = *tx.Expiration
+	}
+	return &logical.Response{Data: respData}, nil
+}
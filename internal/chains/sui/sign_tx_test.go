@@ -0,0 +1,114 @@
+package sui_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// transferTx собирает TransactionData: SplitCoins(GasCoin, [Input(0)]) и TransferObjects([NestedResult(0, 0)], Input(1))
+func transferTx(sender, gasOwner string) []byte {
+	senderBytes, _ := hex.DecodeString(strings.TrimPrefix(sender, "0x"))
+	ownerBytes, _ := hex.DecodeString(strings.TrimPrefix(gasOwner, "0x"))
+
+	tx := []byte{0x00, 0x00} // V1, ProgrammableTransaction
+	tx = append(tx, 0x02)    // inputs
+	tx = append(tx, 0x00, 0x08)
+	tx = binary.LittleEndian.AppendUint64(tx, 1_000_000_000)
+	tx = append(tx, 0x00, 0x20)
+	tx = append(tx, make([]byte, 32)...)
+
+	tx = append(tx, 0x02)                                                       // commands
+	tx = append(tx, 0x02, 0x00, 0x01, 0x01, 0x00, 0x00)                         // SplitCoins(GasCoin, [Input(0)])
+	tx = append(tx, 0x01, 0x01, 0x03, 0x00, 0x00, 0x00, 0x00, 0x01, 0x01, 0x00) // TransferObjects
+
+	tx = append(tx, senderBytes...)
+	tx = append(tx, 0x01) // gas payment: ObjectRef
+	tx = append(tx, make([]byte, 32)...)
+	tx = binary.LittleEndian.AppendUint64(tx, 42)
+	tx = append(append(tx, 0x20), make([]byte, 32)...)
+	tx = append(tx, ownerBytes...)
+	tx = binary.LittleEndian.AppendUint64(tx, 1000)      // price
+	tx = binary.LittleEndian.AppendUint64(tx, 5_000_000) // budget
+	return append(tx, 0x00)                              // expiration: None
+}
+
+func TestSuiSignTx(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createSuiAccount(t, b, storage)
+	txBytes := transferTx(address, address)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/sui/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":  address,
+		"tx_bytes": base64.StdEncoding.EncodeToString(txBytes),
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SplitCoins", "TransferObjects"}, resp.Data["commands"])
+	assert.Equal(t, uint64(1000), resp.Data["gas_price"])
+	assert.Equal(t, uint64(5_000_000), resp.Data["gas_budget"])
+
+	// flag || signature || public key, подпись над Blake2b-256(intent || tx)
+	serialized, err := base64.StdEncoding.DecodeString(resp.Data["signature"].(string))
+	require.NoError(t, err)
+	require.Len(t, serialized, 1+64+32)
+	assert.Equal(t, byte(0x00), serialized[0])
+	assert.Equal(t, []byte(pub), serialized[65:])
+	digest := blake2b.Sum256(append([]byte{0, 0, 0}, txBytes...))
+	assert.True(t, ed25519.Verify(pub, digest[:], serialized[1:65]))
+
+	txDigest := blake2b.Sum256(append([]byte("TransactionData::"), txBytes...))
+	assert.Equal(t, base58.Encode(txDigest[:]), resp.Data["digest"])
+
+	// спонсор газа тоже может подписать
+	sponsored := transferTx("0x"+strings.Repeat("ab", 32), address)
+	req.Data["tx_bytes"] = base64.StdEncoding.EncodeToString(sponsored)
+	_, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+}
+
+func TestSuiSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, _ := createSuiAccount(t, b, storage)
+	txBytes := transferTx(address, address)
+	foreign := "0x" + strings.Repeat("ab", 32)
+
+	for _, tx := range [][]byte{
+		nil,
+		txBytes[:len(txBytes)-1],
+		append(append([]byte{}, txBytes...), 0x00),
+		append([]byte{0x01}, txBytes[1:]...),
+		transferTx(foreign, foreign),
+	} {
+		req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/sui/svc/sign-tx")
+		req.Storage = storage
+		req.Data = map[string]interface{}{
+			"address":  address,
+			"tx_bytes": base64.StdEncoding.EncodeToString(tx),
+		}
+		_, err := b.HandleRequest(context.Background(), req)
+		assert.Error(t, err)
+	}
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/sui/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":  address,
+		"tx_bytes": "not base64!",
+	}
+	_, err := b.HandleRequest(context.Background(), req)
+	assert.Error(t, err)
+}
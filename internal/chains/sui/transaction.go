@@ -0,0 +1,432 @@
+package sui
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// TransactionData V1: kind || sender(32) || gas_data || expiration
+const (
+	addressLen       = 32
+	objectDigestLen  = 32
+	kindProgrammable = 0x00
+)
+
+// maxTypeTagDepth ограничивает вложенность vector<…> и generic-параметров
+const maxTypeTagDepth = 16
+
+// варианты Command в ProgrammableTransaction
+var commandNames = map[uint64]string{
+	0: "MoveCall",
+	1: "TransferObjects",
+	2: "SplitCoins",
+	3: "MergeCoins",
+	4: "Publish",
+	5: "MakeMoveVec",
+	6: "Upgrade",
+}
+
+// transactionData — поля TransactionData, которые возвращаются для проверки перед подписью
+type transactionData struct {
+	Sender     string
+	Commands   []string
+	GasOwner   string
+	GasPrice   uint64
+	GasBudget  uint64
+	Expiration *uint64
+}
+
+var errBCSTruncated = errors.New("truncated BCS")
+
+// bcsReader — последовательное чтение BCS без выделения под непроверенные длины
+type bcsReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *bcsReader) bytes(n int) ([]byte, error) {
+	if n < 0 || len(r.b)-r.pos < n {
+		return nil, errBCSTruncated
+	}
+	out := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return out, nil
+}
+
+func (r *bcsReader) byte() (byte, error) {
+	b, err := r.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *bcsReader) u16() (uint16, error) {
+	b, err := r.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (r *bcsReader) u64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// uleb128 — длина вектора или вариант enum (не длиннее u32)
+func (r *bcsReader) uleb128() (uint64, error) {
+	var value uint64
+	for i := 0; i < 5; i++ {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		value |= uint64(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+	return 0, errors.New("uleb128 overflows u32")
+}
+
+// length читает длину вектора; элементов не может быть больше оставшихся байт
+func (r *bcsReader) length() (int, error) {
+	n, err := r.uleb128()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(r.b)-r.pos) {
+		return 0, errBCSTruncated
+	}
+	return int(n), nil
+}
+
+func (r *bcsReader) byteVector() ([]byte, error) {
+	n, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	return r.bytes(n)
+}
+
+func (r *bcsReader) address() (string, error) {
+	b, err := r.bytes(addressLen)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(b), nil
+}
+
+// objectRef — (ObjectID, SequenceNumber, ObjectDigest)
+func (r *bcsReader) objectRef() error {
+	if _, err := r.bytes(addressLen + 8); err != nil {
+		return err
+	}
+	digest, err := r.byteVector()
+	if err != nil {
+		return err
+	}
+	if len(digest) != objectDigestLen {
+		return errors.New("invalid object digest")
+	}
+	return nil
+}
+
+// decodeTransactionData разбирает TransactionData V1 с ProgrammableTransaction
+func decodeTransactionData(raw []byte) (*transactionData, error) {
+	r := &bcsReader{b: raw}
+	version, err := r.uleb128()
+	if err != nil {
+		return nil, err
+	}
+	if version != transactionDataV1 {
+		return nil, fmt.Errorf("unsupported TransactionData version %d", version)
+	}
+	kind, err := r.uleb128()
+	if err != nil {
+		return nil, err
+	}
+	if kind != kindProgrammable {
+		return nil, fmt.Errorf("unsupported transaction kind %d, only programmable transactions are signed", kind)
+	}
+
+	tx := &transactionData{}
+	if err := r.skipInputs(); err != nil {
+		return nil, fmt.Errorf("inputs: %w", err)
+	}
+	if tx.Commands, err = r.commands(); err != nil {
+		return nil, fmt.Errorf("commands: %w", err)
+	}
+	if tx.Sender, err = r.address(); err != nil {
+		return nil, err
+	}
+
+	// GasData { payment: Vec<ObjectRef>, owner, price, budget }
+	payments, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < payments; i++ {
+		if err := r.objectRef(); err != nil {
+			return nil, fmt.Errorf("gas payment: %w", err)
+		}
+	}
+	if tx.GasOwner, err = r.address(); err != nil {
+		return nil, err
+	}
+	if tx.GasPrice, err = r.u64(); err != nil {
+		return nil, err
+	}
+	if tx.GasBudget, err = r.u64(); err != nil {
+		return nil, err
+	}
+
+	// TransactionExpiration: None | Epoch(u64)
+	expiration, err := r.uleb128()
+	if err != nil {
+		return nil, err
+	}
+	switch expiration {
+	case 0:
+	case 1:
+		epoch, err := r.u64()
+		if err != nil {
+			return nil, err
+		}
+		tx.Expiration = &epoch
+	default:
+		return nil, fmt.Errorf("unsupported expiration variant %d", expiration)
+	}
+
+	if r.pos != len(raw) {
+		return nil, errors.New("trailing bytes after TransactionData")
+	}
+	return tx, nil
+}
+
+// skipInputs пропускает Vec<CallArg>: Pure(bytes) | Object(ObjectArg)
+func (r *bcsReader) skipInputs() error {
+	n, err := r.length()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		variant, err := r.uleb128()
+		if err != nil {
+			return err
+		}
+		switch variant {
+		case 0: // Pure
+			if _, err := r.byteVector(); err != nil {
+				return err
+			}
+		case 1: // Object
+			if err := r.skipObjectArg(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported CallArg variant %d", variant)
+		}
+	}
+	return nil
+}
+
+// skipObjectArg: ImmOrOwnedObject(ObjectRef) | SharedObject{id, initial_shared_version, mutable} | Receiving(ObjectRef)
+func (r *bcsReader) skipObjectArg() error {
+	variant, err := r.uleb128()
+	if err != nil {
+		return err
+	}
+	switch variant {
+	case 0, 2:
+		return r.objectRef()
+	case 1:
+		_, err := r.bytes(addressLen + 8 + 1)
+		return err
+	}
+	return fmt.Errorf("unsupported ObjectArg variant %d", variant)
+}
+
+// commands возвращает имена команд; MoveCall — вместе с функцией
+func (r *bcsReader) commands() ([]string, error) {
+	n, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		variant, err := r.uleb128()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := commandNames[variant]
+		if !ok {
+			return nil, fmt.Errorf("unsupported Command variant %d", variant)
+		}
+		switch variant {
+		case 0: // MoveCall { package, module, function, type_arguments, arguments }
+			pkg, err := r.address()
+			if err != nil {
+				return nil, err
+			}
+			module, err := r.byteVector()
+			if err != nil {
+				return nil, err
+			}
+			function, err := r.byteVector()
+			if err != nil {
+				return nil, err
+			}
+			if err := r.skipTypeTags(0); err != nil {
+				return nil, err
+			}
+			if err := r.skipArguments(); err != nil {
+				return nil, err
+			}
+			name = fmt.Sprintf("%s %s::%s::%s", name, pkg, module, function)
+		case 1: // TransferObjects(Vec<Argument>, Argument)
+			if err := r.skipArguments(); err != nil {
+				return nil, err
+			}
+			if err := r.skipArgument(); err != nil {
+				return nil, err
+			}
+		case 2, 3: // SplitCoins / MergeCoins (Argument, Vec<Argument>)
+			if err := r.skipArgument(); err != nil {
+				return nil, err
+			}
+			if err := r.skipArguments(); err != nil {
+				return nil, err
+			}
+		case 4: // Publish(Vec<Vec<u8>>, Vec<ObjectID>)
+			if err := r.skipModulesAndDeps(); err != nil {
+				return nil, err
+			}
+		case 5: // MakeMoveVec(Option<TypeTag>, Vec<Argument>)
+			some, err := r.byte()
+			if err != nil {
+				return nil, err
+			}
+			if some == 1 {
+				if err := r.skipTypeTag(0); err != nil {
+					return nil, err
+				}
+			} else if some != 0 {
+				return nil, errors.New("invalid option tag")
+			}
+			if err := r.skipArguments(); err != nil {
+				return nil, err
+			}
+		case 6: // Upgrade(Vec<Vec<u8>>, Vec<ObjectID>, ObjectID, Argument)
+			if err := r.skipModulesAndDeps(); err != nil {
+				return nil, err
+			}
+			if _, err := r.bytes(addressLen); err != nil {
+				return nil, err
+			}
+			if err := r.skipArgument(); err != nil {
+				return nil, err
+			}
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (r *bcsReader) skipModulesAndDeps() error {
+	modules, err := r.length()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < modules; i++ {
+		if _, err := r.byteVector(); err != nil {
+			return err
+		}
+	}
+	deps, err := r.length()
+	if err != nil {
+		return err
+	}
+	_, err = r.bytes(deps * addressLen)
+	return err
+}
+
+// skipArgument: GasCoin | Input(u16) | Result(u16) | NestedResult(u16, u16)
+func (r *bcsReader) skipArgument() error {
+	variant, err := r.uleb128()
+	if err != nil {
+		return err
+	}
+	switch variant {
+	case 0:
+		return nil
+	case 1, 2:
+		_, err := r.u16()
+		return err
+	case 3:
+		_, err := r.bytes(4)
+		return err
+	}
+	return fmt.Errorf("unsupported Argument variant %d", variant)
+}
+
+func (r *bcsReader) skipArguments() error {
+	n, err := r.length()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err := r.skipArgument(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *bcsReader) skipTypeTags(depth int) error {
+	n, err := r.length()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err := r.skipTypeTag(depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipTypeTag: примитивы без данных, vector(TypeTag), struct(StructTag)
+func (r *bcsReader) skipTypeTag(depth int) error {
+	if depth > maxTypeTagDepth {
+		return errors.New("type tag nesting is too deep")
+	}
+	variant, err := r.uleb128()
+	if err != nil {
+		return err
+	}
+	switch variant {
+	case 0, 1, 2, 3, 4, 5, 8, 9, 10: // bool, u8, u64, u128, address, signer, u16, u32, u256
+		return nil
+	case 6: // vector
+		return r.skipTypeTag(depth + 1)
+	case 7: // StructTag { address, module, name, type_params }
+		if _, err := r.bytes(addressLen); err != nil {
+			return err
+		}
+		if _, err := r.byteVector(); err != nil {
+			return err
+		}
+		if _, err := r.byteVector(); err != nil {
+			return err
+		}
+		return r.skipTypeTags(depth + 1)
+	}
+	return fmt.Errorf("unsupported TypeTag variant %d", variant)
+}
@@ -0,0 +1,73 @@
+package sui
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/logical"
+	"golang.org/x/crypto/blake2b"
+)
+
+// hdPathFormat — SLIP-0010 путь Sui Wallet и Sui SDK, %d заменяется индексом аккаунта
+const hdPathFormat = "m/44'/784'/%d'/0'/0'"
+
+// ed25519Flag — флаг схемы подписи: в адресе, в сериализованной подписи и в suiprivkey
+const ed25519Flag = 0x00
+
+// privateKeyHRP — bech32 префикс приватного ключа (sui keytool export)
+const privateKeyHRP = "suiprivkey"
+
+// DeriveAddress returns the Sui address: Blake2b-256(flag || public key), 0x-hex.
+func DeriveAddress(pub []byte) string {
+	h := blake2b.Sum256(append([]byte{ed25519Flag}, pub...))
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+// parsePrivateKey принимает 32-байтовый seed в hex или bech32 suiprivkey1… со схемой ed25519
+func parsePrivateKey(input string) ([]byte, error) {
+	if strings.HasPrefix(input, privateKeyHRP+"1") {
+		hrp, data, err := bech32.Decode(input)
+		if err != nil || hrp != privateKeyHRP {
+			return nil, fmt.Errorf("invalid suiprivkey: %v", err)
+		}
+		raw, err := bech32.ConvertBits(data, 5, 8, false)
+		if err != nil || len(raw) != 1+ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid suiprivkey length")
+		}
+		if raw[0] != ed25519Flag {
+			return nil, fmt.Errorf("unsupported suiprivkey scheme flag 0x%02x, only ed25519 is supported", raw[0])
+		}
+		return raw[1:], nil
+	}
+	seed, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid private key: expected a 32-byte hex ed25519 seed or suiprivkey1…")
+	}
+	return seed, nil
+}
+
+// loadKey достаёт ключ сервиса по адресу
+func loadKey(ctx context.Context, req *logical.Request, name, address string) (ed25519.PrivateKey, error) {
+	kp, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.SUI)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing key for address %s: %w", address, err)
+	}
+	seed, err := hex.DecodeString(kp.PrivateKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("stored private key is not a valid seed")
+	}
+	defer zero(seed)
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"fmt"
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
 	"github.com/dsshard/vault-crypto-adapters/internal/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/tonkeeper/tongo/ton"
+	"math"
 	"strings"
 
 	"github.com/hashicorp/vault/sdk/framework"
@@ -25,7 +28,7 @@ func PathCrud() *framework.Path {
 				Callback: createKeyManager,
 			},
 			logical.ReadOperation: &framework.PathOperation{
-				Callback: backend.WrapperReadKeyManager(config.Chain.TON),
+				Callback: readKeyManager,
 			},
 			logical.DeleteOperation: &framework.PathOperation{
 				Callback: backend.WrapperDeleteKeyManager(config.Chain.TON),
@@ -34,10 +37,63 @@ func PathCrud() *framework.Path {
 		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.TON),
 		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
 		HelpDescription: backend.DefaultHelpDescriptionCreateList,
-		Fields:          backend.DefaultCrudOperations,
+		Fields:          createFields,
 	}
 }
 
+var createFields = backend.CrudFields(map[string]*framework.FieldSchema{
+	"wallet_version": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Wallet contract version: v3r2, v4r2, v5r1 or highload-v3. Defaults to config/ton, then v4r2",
+	},
+	"workchain": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) Workchain of the wallet: 0 (basechain) or -1 (masterchain). Defaults to config/ton, then 0",
+	},
+	"subwallet_id": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) Subwallet id. Defaults to 698983191 + workchain for v3r2/v4r2, 0 for v5r1 and 698983191 for highload-v3",
+	},
+	"highload_timeout": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) highload-v3 query timeout in seconds, part of the wallet address. Defaults to 3600",
+	},
+	"bounceable": {
+		Type:        framework.TypeBool,
+		Description: "(Optional) Return the user-friendly address in bounceable (EQ…) form. Defaults to true",
+		Default:     true,
+	},
+	"testnet": {
+		Type:        framework.TypeBool,
+		Description: "(Optional) Testnet wallet: testnet-only user-friendly form (kQ…/0Q…) and testnet wallet id for v5r1",
+		Default:     false,
+	},
+})
+
+// readKeyManager дополняет пары raw-адресом; старые записи — v4r2 на basechain, bounceable
+func readKeyManager(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	resp, err := backend.WrapperReadKeyManager(config.Chain.TON)(ctx, req, data)
+	if err != nil || resp == nil || resp.Data == nil {
+		return resp, err
+	}
+	pairs, _ := resp.Data["key_pairs"].([]map[string]interface{})
+	for _, pair := range pairs {
+		if _, ok := pair["wallet_version"]; !ok {
+			pair["wallet_version"] = defaultWalletVersion
+		}
+		if _, ok := pair["workchain"]; !ok {
+			pair["workchain"] = int32(0)
+		}
+		if _, ok := pair["bounceable"]; !ok {
+			pair["bounceable"] = true
+		}
+		if addr, err := ton.ParseAccountID(pair["address"].(string)); err == nil {
+			pair["raw_address"] = addr.ToRaw()
+		}
+	}
+	return resp, nil
+}
+
 func createKeyManager(
 	ctx context.Context,
 	req *logical.Request,
@@ -54,6 +110,33 @@ func createKeyManager(
 		return nil, fmt.Errorf("private_key must be a hex string")
 	}
 
+	cfg, err := backend.GetChainConfig(ctx, req.Storage, config.Chain.TON)
+	if err != nil {
+		return nil, err
+	}
+	requestedVersion := data.Get("wallet_version").(string)
+	if requestedVersion == "" {
+		requestedVersion = cfg.WalletVersion
+	}
+	walletVersion, err := parseWalletVersion(requestedVersion)
+	if err != nil {
+		return nil, err
+	}
+	var workchain int32
+	if raw, ok := data.GetOk("workchain"); ok {
+		workchain = int32(raw.(int))
+	} else if cfg.Workchain != nil {
+		workchain = *cfg.Workchain
+	}
+	if err := validateWorkchain(workchain); err != nil {
+		return nil, err
+	}
+	params, err := walletParamsFromData(data, walletVersion, workchain)
+	if err != nil {
+		return nil, err
+	}
+	bounceable := data.Get("bounceable").(bool)
+
 	// retrieve or init KeyManager
 	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.TON, serviceName)
 	if err != nil {
@@ -63,9 +146,25 @@ func createKeyManager(
 		km = &types.KeyManager{ServiceName: serviceName}
 	}
 
-	// generate or import ed25519 key
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive, import or generate ed25519 key
 	var seed []byte
-	if privateKey != "" {
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privateKey != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormat)
+		seed, err = hd.DeriveEd25519(km.Mnemonic, km.Passphrase, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+	} else if privateKey != "" {
 		// strip optional 0x
 		hexStr := strings.TrimPrefix(privateKey, "0x")
 		bs, err := hex.DecodeString(hexStr)
@@ -84,13 +183,28 @@ func createKeyManager(
 	pub := priv.Public().(ed25519.PublicKey) // 32-byte public key
 	defer zeroSeed(seed)                     // wipe seed from memory
 
-	// derive TON address (implement in utils.go)
-	addr := DeriveAddress(pub)
+	// derive TON address
+	addr, err := DeriveAddress(pub, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive wallet address: %w", err)
+	}
 
 	kp := &types.KeyPair{
-		PrivateKey: hex.EncodeToString(seed),
-		PublicKey:  hex.EncodeToString(pub),
-		Address:    addr,
+		PrivateKey:    hex.EncodeToString(seed),
+		PublicKey:     hex.EncodeToString(pub),
+		Address:       addr.ToHuman(bounceable, params.Testnet),
+		WalletVersion: walletVersion,
+		Workchain:     &workchain,
+		SubwalletID:   &params.SubwalletID,
+		Bounceable:    &bounceable,
+		Testnet:       params.Testnet,
+	}
+	if walletVersion == WalletHighloadV3 {
+		kp.HighloadTimeout = params.Timeout
+	}
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
 	}
 	km.KeyPairs = append(km.KeyPairs, kp)
 
@@ -101,15 +215,64 @@ func createKeyManager(
 		return nil, err
 	}
 
+	respData := map[string]interface{}{
+		"service_name":   km.ServiceName,
+		"address":        kp.Address,
+		"public_key":     kp.PublicKey,
+		"raw_address":    addr.ToRaw(),
+		"wallet_version": kp.WalletVersion,
+		"workchain":      workchain,
+		"subwallet_id":   params.SubwalletID,
+		"bounceable":     bounceable,
+		"testnet":        params.Testnet,
+	}
+	if kp.HighloadTimeout != 0 {
+		respData["highload_timeout"] = kp.HighloadTimeout
+	}
+	backend.AddHDResponseData(respData, kp, generatedMnemonic)
+
 	return &logical.Response{
-		Data: map[string]interface{}{
-			"service_name": km.ServiceName,
-			"address":      kp.Address,
-			"public_key":   kp.PublicKey,
-		},
+		Data: respData,
 	}, nil
 }
 
+// walletParamsFromData собирает параметры контракта из запроса, подставляя значения по умолчанию версии
+func walletParamsFromData(data *framework.FieldData, version string, workchain int32) (WalletParams, error) {
+	params := WalletParams{
+		Version:     version,
+		Workchain:   workchain,
+		SubwalletID: defaultSubwalletID(version, workchain),
+		Testnet:     data.Get("testnet").(bool),
+	}
+	if raw, ok := data.GetOk("subwallet_id"); ok {
+		id := raw.(int)
+		if id < 0 || id > math.MaxUint32 {
+			return params, fmt.Errorf("subwallet_id must be between 0 and %d", uint32(math.MaxUint32))
+		}
+		params.SubwalletID = uint32(id)
+	}
+	if err := validateSubwalletID(version, params.SubwalletID); err != nil {
+		return params, err
+	}
+
+	raw, ok := data.GetOk("highload_timeout")
+	if version != WalletHighloadV3 {
+		if ok {
+			return params, fmt.Errorf("highload_timeout applies to %s wallets only", WalletHighloadV3)
+		}
+		return params, nil
+	}
+	params.Timeout = defaultHighloadTimeout
+	if ok {
+		timeout := raw.(int)
+		if timeout <= 0 || timeout > maxHighloadTimeout {
+			return params, fmt.Errorf("highload_timeout must be between 1 and %d", maxHighloadTimeout)
+		}
+		params.Timeout = uint32(timeout)
+	}
+	return params, nil
+}
+
 // zeroSeed overwrites the seed bytes in memory.
 func zeroSeed(b []byte) {
 	for i := range b {
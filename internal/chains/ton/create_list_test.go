@@ -2,13 +2,17 @@ package ton_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/hex"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/dsshard/vault-crypto-adapters/internal/test"
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tonkeeper/tongo/wallet"
 )
 
 func TestTonCreateAndListKeyManagers(t *testing.T) {
@@ -83,3 +87,160 @@ func TestTonCreateAndListKeyManagers_InvalidPrivKey(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid private key")
 }
+
+func TestTonCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ton/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"hd": true}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/607'/0'", resp.Data["derivation_path"])
+	mnemonic := resp.Data["mnemonic"].(string)
+
+	// the same mnemonic restores the same address in another service
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ton/restored")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"mnemonic": mnemonic}
+	restored, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, resp.Data["address"], restored.Data["address"])
+	assert.Equal(t, resp.Data["public_key"], restored.Data["public_key"])
+}
+
+func TestTonCreateKeyManagers_MountConfig(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.UpdateOperation, "config/ton")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"wallet_version": "v3r2", "workchain": -1}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ton/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "4c0883a69102937a9280f1222f7c9b6645e1a3c7bf2e5b4cd0bd58d7f9f5d9b1",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "v3r2", resp.Data["wallet_version"])
+	assert.Equal(t, int32(-1), resp.Data["workchain"])
+	// bounceable masterchain address
+	assert.True(t, strings.HasPrefix(resp.Data["address"].(string), "Ef_"))
+	assert.NotEqual(t, "EQB2trRSt_ZF-gnMRgJhu_oORG6W0T8Ja75CmjnjRR1mRYL0", resp.Data["address"])
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/ton/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"wallet_version": "v9"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+}
+
+func TestTonCreateKeyManagers_WalletVersions(t *testing.T) {
+	const seedHex = "4c0883a69102937a9280f1222f7c9b6645e1a3c7bf2e5b4cd0bd58d7f9f5d9b1"
+	seed, err := hex.DecodeString(seedHex)
+	require.NoError(t, err)
+	pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+	// W5 with the default wallet id must match tongo's implementation
+	w5Mainnet, err := wallet.GenerateWalletAddress(pub, wallet.V5R1, nil, 0, nil)
+	require.NoError(t, err)
+	testnetID := int32(wallet.TestnetGlobalID)
+	w5Testnet, err := wallet.GenerateWalletAddress(pub, wallet.V5R1, &testnetID, 0, nil)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		data    map[string]interface{}
+		address string
+		raw     string
+		subID   uint32
+	}{
+		{
+			name:    "v5r1",
+			data:    map[string]interface{}{"wallet_version": "v5r1"},
+			address: w5Mainnet.ToHuman(true, false),
+			raw:     w5Mainnet.ToRaw(),
+		},
+		{
+			name:    "v5r1 testnet non-bounceable",
+			data:    map[string]interface{}{"wallet_version": "v5r1", "testnet": true, "bounceable": false},
+			address: w5Testnet.ToHuman(false, true),
+			raw:     w5Testnet.ToRaw(),
+		},
+		{
+			name:    "v4r2 non-bounceable",
+			data:    map[string]interface{}{"bounceable": false},
+			address: "UQB2trRSt_ZF-gnMRgJhu_oORG6W0T8Ja75CmjnjRR1mRd8x",
+			subID:   698983191,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, storage := test.NewTestBackend(t)
+			req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ton/svc")
+			req.Storage = storage
+			req.Data = map[string]interface{}{"private_key": seedHex}
+			for k, v := range tc.data {
+				req.Data[k] = v
+			}
+			resp, err := b.HandleRequest(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.address, resp.Data["address"])
+			if tc.raw != "" {
+				assert.Equal(t, tc.raw, resp.Data["raw_address"])
+			}
+			assert.Equal(t, tc.subID, resp.Data["subwallet_id"])
+		})
+	}
+}
+
+func TestTonCreateKeyManagers_HighloadV3(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	create := func(data map[string]interface{}) (*logical.Response, error) {
+		req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ton/svc")
+		req.Storage = storage
+		req.Data = map[string]interface{}{
+			"private_key":    "4c0883a69102937a9280f1222f7c9b6645e1a3c7bf2e5b4cd0bd58d7f9f5d9b1",
+			"wallet_version": "highload-v3",
+		}
+		for k, v := range data {
+			req.Data[k] = v
+		}
+		return b.HandleRequest(context.Background(), req)
+	}
+
+	resp, err := create(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3600), resp.Data["highload_timeout"])
+	assert.Regexp(t, `^0:[0-9a-f]{64}$`, resp.Data["raw_address"])
+	defaultAddr := resp.Data["address"]
+
+	// subwallet id and timeout are part of the contract state
+	resp, err = create(map[string]interface{}{"subwallet_id": 1})
+	require.NoError(t, err)
+	assert.NotEqual(t, defaultAddr, resp.Data["address"])
+	resp, err = create(map[string]interface{}{"highload_timeout": 60})
+	require.NoError(t, err)
+	assert.NotEqual(t, defaultAddr, resp.Data["address"])
+
+	_, err = create(map[string]interface{}{"highload_timeout": 1 << 22})
+	require.Error(t, err)
+	_, err = create(map[string]interface{}{"wallet_version": "v4r2", "highload_timeout": 60})
+	require.Error(t, err)
+	_, err = create(map[string]interface{}{"wallet_version": "v5r1", "subwallet_id": 1 << 15})
+	require.Error(t, err)
+
+	req := logical.TestRequest(t, logical.ReadOperation, "key-managers/ton/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, pairs, 3)
+	assert.Equal(t, "highload-v3", pairs[0]["wallet_version"])
+	assert.Equal(t, uint32(3600), pairs[0]["highload_timeout"])
+	assert.Equal(t, true, pairs[0]["bounceable"])
+	assert.Regexp(t, `^0:[0-9a-f]{64}$`, pairs[0]["raw_address"])
+}
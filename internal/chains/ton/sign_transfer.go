@@ -0,0 +1,454 @@
+package ton
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/tonkeeper/tongo/boc"
+	"github.com/tonkeeper/tongo/tlb"
+	"github.com/tonkeeper/tongo/ton"
+	"github.com/tonkeeper/tongo/wallet"
+)
+
+// maxMessages — сколько внутренних сообщений принимает одно внешнее сообщение кошелька
+var maxMessages = map[string]int{
+	WalletV3R2:       4,
+	WalletV4R2:       4,
+	WalletV5R1:       255,
+	WalletHighloadV3: 1,
+}
+
+// maxHighloadQueryID — query_id highload v3: shift (13 бит) << 10 | bit_number (10 бит, не больше 1022)
+const maxHighloadQueryID = 1<<23 - 1
+
+// highloadCreatedAtLag — запас created_at относительно текущего времени на расхождение часов с валидаторами
+const highloadCreatedAtLag = 60
+
+var signTransferFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The wallet address that belongs to a private key in the key-manager.",
+	},
+	"messages": {
+		Type: framework.TypeSlice,
+		Description: `Internal messages: [{"destination": "EQ…", "amount": "1000000000", "comment": "…" | "payload": "<base64 BOC>", "bounce": true, "mode": 3}]. ` +
+			"bounce defaults to the flag of the destination address, mode to 3.",
+	},
+	"seqno": {
+		Type:        framework.TypeInt,
+		Description: "Current seqno of the wallet (v3r2, v4r2, v5r1).",
+	},
+	"valid_until": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) Unix time the message expires at (v3r2, v4r2, v5r1). Defaults to now + 3 minutes.",
+	},
+	"query_id": {
+		Type:        framework.TypeInt,
+		Description: "Query id of a highload-v3 message: shift << 10 | bit_number, bit_number up to 1022.",
+	},
+	"created_at": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) Unix time of a highload-v3 message. Defaults to now - 60 seconds.",
+	},
+	"deploy": {
+		Type:        framework.TypeBool,
+		Description: "(Optional) Attach the wallet StateInit to deploy it with this message. Defaults to true when seqno is 0.",
+	},
+}
+
+func PathSignTransfer() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTransfer(config.Chain.TON),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: signTransfer,
+			},
+		},
+		HelpSynopsis:    "Build and sign a TON wallet transfer.",
+		HelpDescription: "POST address, messages, seqno [+ valid_until, deploy] (query_id [+ created_at] for highload-v3) → boc (base64 external message, ready for sendBoc) and hash.",
+		Fields:          signTransferFields,
+	}
+}
+
+// transferMessage — внутреннее сообщение из запроса
+type transferMessage struct {
+	Destination string      `json:"destination"`
+	Amount      json.Number `json:"amount"`
+	Comment     string      `json:"comment"`
+	Payload     string      `json:"payload"`
+	Bounce      *bool       `json:"bounce"`
+	Mode        *uint8      `json:"mode"`
+}
+
+func signTransfer(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	keyPair, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.TON)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing keyManager %s", address)
+	}
+	params, err := walletParamsFromKeyPair(keyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := hex.DecodeString(keyPair.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored seed hex: %w", err)
+	}
+	priv := ed25519.NewKeyFromSeed(seed)
+	defer zeroSeed(seed)
+	defer zeroSeed(priv)
+	pub := priv.Public().(ed25519.PublicKey)
+
+	// адрес получателя внешнего сообщения — контракт, описанный параметрами ключа
+	walletAddr, err := DeriveAddress(pub, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive wallet address: %w", err)
+	}
+
+	messages, err := parseTransferMessages(data.Get("messages"), params.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	respData := map[string]interface{}{
+		"address":        address,
+		"wallet_version": params.Version,
+	}
+	var body *boc.Cell
+	var deployByDefault bool
+	if params.Version == WalletHighloadV3 {
+		if _, ok := data.GetOk("seqno"); ok {
+			return nil, fmt.Errorf("seqno does not apply to %s wallets, use query_id", WalletHighloadV3)
+		}
+		queryID, createdAt, err := highloadQueryParams(data, params.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		body, err = highloadV3Body(priv, params, messages[0], queryID, createdAt)
+		if err != nil {
+			return nil, err
+		}
+		respData["query_id"] = queryID
+		respData["created_at"] = createdAt
+	} else {
+		if _, ok := data.GetOk("query_id"); ok {
+			return nil, fmt.Errorf("query_id applies to %s wallets only", WalletHighloadV3)
+		}
+		rawSeqno, ok := data.GetOk("seqno")
+		if !ok {
+			return nil, fmt.Errorf("seqno is required")
+		}
+		if rawSeqno.(int) < 0 || rawSeqno.(int) > math.MaxUint32 {
+			return nil, fmt.Errorf("seqno must be between 0 and %d", uint32(math.MaxUint32))
+		}
+		seqno := uint32(rawSeqno.(int))
+		validUntil := time.Now().Add(wallet.DefaultMessageLifetime).Unix()
+		if raw, ok := data.GetOk("valid_until"); ok {
+			validUntil = int64(raw.(int))
+		}
+		if validUntil <= time.Now().Unix() || validUntil > math.MaxUint32 {
+			return nil, fmt.Errorf("valid_until must be a future unix time")
+		}
+		body, err = seqnoWalletBody(priv, params, messages, seqno, uint32(validUntil))
+		if err != nil {
+			return nil, err
+		}
+		deployByDefault = seqno == 0
+		respData["seqno"] = seqno
+		respData["valid_until"] = validUntil
+	}
+
+	// StateInit нужен, пока кошелёк не развёрнут: без него внешнее сообщение некуда доставить
+	deploy := deployByDefault
+	if raw, ok := data.GetOk("deploy"); ok {
+		deploy = raw.(bool)
+	}
+	var init *tlb.StateInit
+	if deploy {
+		if init, err = stateInit(pub, params); err != nil {
+			return nil, err
+		}
+	}
+
+	extMsg, err := ton.CreateExternalMessage(walletAddr, body, init, tlb.VarUInteger16{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create external message: %w", err)
+	}
+	extMsgCell := boc.NewCell()
+	if err := tlb.Marshal(extMsgCell, extMsg); err != nil {
+		return nil, fmt.Errorf("failed to encode external message: %w", err)
+	}
+	hash, err := extMsgCell.Hash256()
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := extMsgCell.ToBocBase64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize external message: %w", err)
+	}
+
+	respData["boc"] = encoded
+	respData["hash"] = hex.EncodeToString(hash[:])
+	respData["deploy"] = deploy
+	return &logical.Response{Data: respData}, nil
+}
+
+// parseTransferMessages разбирает messages в ячейки внутренних сообщений; неизвестные поля — ошибка
+func parseTransferMessages(raw interface{}, version string) ([]wallet.RawMessage, error) {
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid messages: %w", err)
+	}
+	var items []transferMessage
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&items); err != nil {
+		return nil, fmt.Errorf("invalid messages: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("at least one message is required")
+	}
+	if len(items) > maxMessages[version] {
+		return nil, fmt.Errorf("%s wallets accept up to %d messages", version, maxMessages[version])
+	}
+
+	messages := make([]wallet.RawMessage, 0, len(items))
+	for i, item := range items {
+		msg, err := item.toRawMessage()
+		if err != nil {
+			return nil, fmt.Errorf("invalid message %d: %w", i, err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+func (m transferMessage) toRawMessage() (wallet.RawMessage, error) {
+	dest, bounce, err := parseDestination(m.Destination)
+	if err != nil {
+		return wallet.RawMessage{}, err
+	}
+	if m.Bounce != nil {
+		bounce = *m.Bounce
+	}
+	amount, err := strconv.ParseUint(m.Amount.String(), 10, 64)
+	if err != nil {
+		return wallet.RawMessage{}, fmt.Errorf("amount must be an integer number of nanotons")
+	}
+	mode := uint8(wallet.DefaultMessageMode)
+	if m.Mode != nil {
+		mode = *m.Mode
+	}
+
+	var body *boc.Cell
+	switch {
+	case m.Comment != "" && m.Payload != "":
+		return wallet.RawMessage{}, fmt.Errorf("comment and payload are mutually exclusive")
+	case m.Comment != "":
+		body = boc.NewCell()
+		if err := tlb.Marshal(body, wallet.TextComment(m.Comment)); err != nil {
+			return wallet.RawMessage{}, fmt.Errorf("failed to encode comment: %w", err)
+		}
+	case m.Payload != "":
+		cells, err := boc.DeserializeBocBase64(m.Payload)
+		if err != nil || len(cells) != 1 {
+			return wallet.RawMessage{}, fmt.Errorf("payload must be a base64 BOC with a single root cell")
+		}
+		body = cells[0]
+	}
+
+	intMsg, _, err := wallet.Message{
+		Amount:  tlb.Grams(amount),
+		Address: dest,
+		Body:    body,
+		Bounce:  bounce,
+	}.ToInternal()
+	if err != nil {
+		return wallet.RawMessage{}, err
+	}
+	cell := boc.NewCell()
+	if err := tlb.Marshal(cell, intMsg); err != nil {
+		return wallet.RawMessage{}, fmt.Errorf("failed to encode message: %w", err)
+	}
+	return wallet.RawMessage{Message: cell, Mode: mode}, nil
+}
+
+// parseDestination принимает raw (0:…) и user-friendly адреса; bounce по умолчанию берётся из флага адреса
+func parseDestination(s string) (ton.AccountID, bool, error) {
+	s = strings.TrimSpace(s)
+	if id, err := ton.AccountIDFromRaw(s); err == nil {
+		return id, true, nil
+	}
+	id, err := ton.AccountIDFromBase64Url(s)
+	if err != nil {
+		return ton.AccountID{}, false, fmt.Errorf("invalid destination %q", s)
+	}
+	// первый байт user-friendly адреса: 0x11 — bounceable, 0x51 — non-bounceable, 0x80 — testnet
+	tag, _ := base64.URLEncoding.DecodeString(strings.NewReplacer("+", "-", "/", "_").Replace(s))
+	return id, tag[0]&0x40 == 0, nil
+}
+
+// seqnoWalletBody собирает и подписывает тело внешнего сообщения v3r2 / v4r2 / v5r1
+func seqnoWalletBody(priv ed25519.PrivateKey, p WalletParams, messages []wallet.RawMessage, seqno, validUntil uint32) (*boc.Cell, error) {
+	var unsigned any
+	switch p.Version {
+	case WalletV3R2:
+		unsigned = wallet.MessageV3{
+			SubWalletId: p.SubwalletID,
+			ValidUntil:  validUntil,
+			Seqno:       seqno,
+			RawMessages: messages,
+		}
+	case WalletV4R2:
+		unsigned = wallet.MessageV4{
+			SubWalletId: p.SubwalletID,
+			ValidUntil:  validUntil,
+			Seqno:       seqno,
+			RawMessages: messages,
+		}
+	case WalletV5R1:
+		return v5SignedBody(priv, p, messages, seqno, validUntil)
+	default:
+		return nil, fmt.Errorf("unsupported wallet_version %q", p.Version)
+	}
+
+	// v3 / v4: signature(512) || body, подпись — по хешу ячейки body
+	bodyCell := boc.NewCell()
+	if err := tlb.Marshal(bodyCell, unsigned); err != nil {
+		return nil, fmt.Errorf("failed to encode wallet message: %w", err)
+	}
+	signature, err := bodyCell.Sign(priv)
+	if err != nil {
+		return nil, fmt.Errorf("sign failed: %w", err)
+	}
+	signed := wallet.SignedMsgBody{Message: tlb.Any(*bodyCell)}
+	copy(signed.Sign[:], signature)
+	cell := boc.NewCell()
+	if err := tlb.Marshal(cell, signed); err != nil {
+		return nil, fmt.Errorf("failed to encode signed message: %w", err)
+	}
+	return cell, nil
+}
+
+// v5SignedExternal — тело signed_external W5 без подписи
+type v5SignedExternal struct {
+	Magic           tlb.Magic `tlb:"#7369676e"`
+	WalletID        uint32
+	ValidUntil      uint32
+	Seqno           uint32
+	Actions         *wallet.W5Actions         `tlb:"maybe^"`
+	ExtendedActions *wallet.W5ExtendedActions `tlb:"maybe"`
+}
+
+// v5SignedBody — W5: подпись по хешу тела дописывается в конец той же ячейки
+func v5SignedBody(priv ed25519.PrivateKey, p WalletParams, messages []wallet.RawMessage, seqno, validUntil uint32) (*boc.Cell, error) {
+	actions := make(wallet.W5Actions, 0, len(messages))
+	for _, msg := range messages {
+		actions = append(actions, wallet.W5SendMessageAction{Msg: msg.Message, Mode: msg.Mode})
+	}
+	cell := boc.NewCell()
+	err := tlb.Marshal(cell, v5SignedExternal{
+		WalletID:   v5WalletID(p.Workchain, p.SubwalletID, p.Testnet),
+		ValidUntil: validUntil,
+		Seqno:      seqno,
+		Actions:    &actions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode wallet message: %w", err)
+	}
+	signature, err := cell.Sign(priv)
+	if err != nil {
+		return nil, fmt.Errorf("sign failed: %w", err)
+	}
+	if err := cell.WriteBytes(signature); err != nil {
+		return nil, err
+	}
+	return cell, nil
+}
+
+// highloadV3Inner — msg_inner highload v3: подписывается хеш этой ячейки
+type highloadV3Inner struct {
+	SubwalletID uint32
+	Message     *boc.Cell `tlb:"^"`
+	SendMode    uint8
+	QueryID     tlb.Uint23
+	CreatedAt   uint64
+	Timeout     tlb.Uint22
+}
+
+// highloadV3External — тело внешнего сообщения highload v3: signature(512) + ^msg_inner
+type highloadV3External struct {
+	Signature tlb.Bits512
+	Inner     *boc.Cell `tlb:"^"`
+}
+
+func highloadV3Body(priv ed25519.PrivateKey, p WalletParams, msg wallet.RawMessage, queryID uint32, createdAt uint64) (*boc.Cell, error) {
+	inner := boc.NewCell()
+	err := tlb.Marshal(inner, highloadV3Inner{
+		SubwalletID: p.SubwalletID,
+		Message:     msg.Message,
+		SendMode:    msg.Mode,
+		QueryID:     tlb.Uint23(queryID),
+		CreatedAt:   createdAt,
+		Timeout:     tlb.Uint22(p.Timeout),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode wallet message: %w", err)
+	}
+	signature, err := inner.Sign(priv)
+	if err != nil {
+		return nil, fmt.Errorf("sign failed: %w", err)
+	}
+	external := highloadV3External{Inner: inner}
+	copy(external.Signature[:], signature)
+	cell := boc.NewCell()
+	if err := tlb.Marshal(cell, external); err != nil {
+		return nil, fmt.Errorf("failed to encode signed message: %w", err)
+	}
+	return cell, nil
+}
+
+// highloadQueryParams читает query_id и created_at; контракт принимает now - timeout < created_at <= now
+func highloadQueryParams(data *framework.FieldData, timeout uint32) (uint32, uint64, error) {
+	raw, ok := data.GetOk("query_id")
+	if !ok {
+		return 0, 0, fmt.Errorf("query_id is required for %s wallets", WalletHighloadV3)
+	}
+	queryID := raw.(int)
+	if queryID < 0 || queryID > maxHighloadQueryID || queryID&0x3ff == 0x3ff {
+		return 0, 0, fmt.Errorf("query_id must be shift << 10 | bit_number with shift < 8192 and bit_number < 1023")
+	}
+
+	now := time.Now().Unix()
+	createdAt := now - int64(min(highloadCreatedAtLag, timeout/2))
+	if raw, ok := data.GetOk("created_at"); ok {
+		createdAt = int64(raw.(int))
+	}
+	if createdAt > now || createdAt <= now-int64(timeout) {
+		return 0, 0, fmt.Errorf("created_at must be within the last %d seconds", timeout)
+	}
+	return uint32(queryID), uint64(createdAt), nil
+}
@@ -0,0 +1,231 @@
+package ton_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tonkeeper/tongo/boc"
+	"github.com/tonkeeper/tongo/tlb"
+	"github.com/tonkeeper/tongo/ton"
+	"github.com/tonkeeper/tongo/wallet"
+)
+
+const (
+	tonTestSeed        = "4c0883a69102937a9280f1222f7c9b6645e1a3c7bf2e5b4cd0bd58d7f9f5d9b1"
+	tonTestDestination = "UQB2trRSt_ZF-gnMRgJhu_oORG6W0T8Ja75CmjnjRR1mRd8x"
+)
+
+func createTonWallet(t *testing.T, b logical.Backend, storage logical.Storage, data map[string]interface{}) string {
+	t.Helper()
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/ton/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": tonTestSeed}
+	for k, v := range data {
+		req.Data[k] = v
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	return resp.Data["address"].(string)
+}
+
+func decodeExternalMessage(t *testing.T, encoded string) (*boc.Cell, tlb.Message) {
+	t.Helper()
+	cells, err := boc.DeserializeBocBase64(encoded)
+	require.NoError(t, err)
+	require.Len(t, cells, 1)
+	var msg tlb.Message
+	require.NoError(t, tlb.Unmarshal(cells[0], &msg))
+	cells[0].ResetCounters()
+	return cells[0], msg
+}
+
+// TestTonSignTransfer_MatchesTongo сверяет подписанное тело с тем, что собирает сам tongo
+func TestTonSignTransfer_MatchesTongo(t *testing.T) {
+	seed, err := hex.DecodeString(tonTestSeed)
+	require.NoError(t, err)
+	priv := ed25519.NewKeyFromSeed(seed)
+	pub := priv.Public().(ed25519.PublicKey)
+	validUntil := time.Now().Add(time.Minute).Unix()
+
+	for version, ver := range map[string]wallet.Version{"v3r2": wallet.V3R2, "v4r2": wallet.V4R2, "v5r1": wallet.V5R1} {
+		t.Run(version, func(t *testing.T) {
+			b, storage := test.NewTestBackend(t)
+			address := createTonWallet(t, b, storage, map[string]interface{}{"wallet_version": version})
+
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/ton/svc/sign-transfer")
+			req.Storage = storage
+			req.Data = map[string]interface{}{
+				"address": address,
+				"messages": []interface{}{
+					map[string]interface{}{"destination": tonTestDestination, "amount": "1500000000", "comment": "hello"},
+				},
+				"seqno":       7,
+				"valid_until": validUntil,
+			}
+			resp, err := b.HandleRequest(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, false, resp.Data["deploy"])
+
+			cell, msg := decodeExternalMessage(t, resp.Data["boc"].(string))
+			assert.False(t, msg.Init.Exists)
+			dest, err := ton.AccountIDFromTlb(msg.Info.ExtInMsgInfo.Dest)
+			require.NoError(t, err)
+			assert.Equal(t, address, dest.ToHuman(true, false))
+			require.NoError(t, wallet.VerifySignature(ver, cell, pub))
+
+			hash, err := cell.Hash256()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(hash[:]), resp.Data["hash"])
+
+			w, err := wallet.New(priv, ver, nil)
+			require.NoError(t, err)
+			expected, err := w.CreateMessageBody(wallet.MessageConfig{
+				Seqno:      7,
+				ValidUntil: time.Unix(validUntil, 0),
+				V5MsgType:  wallet.V5MsgTypeSignedExternal,
+			}, wallet.SimpleTransfer{
+				Amount:  1500000000,
+				Address: ton.MustParseAccountID(tonTestDestination),
+				Comment: "hello",
+			})
+			require.NoError(t, err)
+			expectedHash, err := expected.Hash256()
+			require.NoError(t, err)
+			body := boc.Cell(msg.Body.Value)
+			bodyHash, err := body.Hash256()
+			require.NoError(t, err)
+			assert.Equal(t, expectedHash, bodyHash)
+		})
+	}
+}
+
+func TestTonSignTransfer_DeployAndMessages(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address := createTonWallet(t, b, storage, nil)
+
+	payload := boc.NewCell()
+	require.NoError(t, payload.WriteUint(0xdeadbeef, 32))
+	encodedPayload, err := payload.ToBocBase64()
+	require.NoError(t, err)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/ton/svc/sign-transfer")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address": address,
+		"messages": []interface{}{
+			map[string]interface{}{"destination": tonTestDestination, "amount": 1000},
+			map[string]interface{}{"destination": address, "amount": "2000", "payload": encodedPayload, "mode": 1},
+		},
+		"seqno": 0,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.Data["deploy"])
+
+	cell, msg := decodeExternalMessage(t, resp.Data["boc"].(string))
+	assert.True(t, msg.Init.Exists)
+	raw, err := wallet.ExtractRawMessages(wallet.V4R2, cell)
+	require.NoError(t, err)
+	require.Len(t, raw, 2)
+	assert.Equal(t, byte(3), raw[0].Mode)
+	assert.Equal(t, byte(1), raw[1].Mode)
+
+	var first, second tlb.Message
+	require.NoError(t, tlb.Unmarshal(raw[0].Message, &first))
+	require.NoError(t, tlb.Unmarshal(raw[1].Message, &second))
+	// bounce следует флагу адреса получателя: UQ… — non-bounceable, EQ… — bounceable
+	assert.False(t, first.Info.IntMsgInfo.Bounce)
+	assert.Equal(t, tlb.Grams(1000), first.Info.IntMsgInfo.Value.Grams)
+	assert.True(t, second.Info.IntMsgInfo.Bounce)
+	secondBody := boc.Cell(second.Body.Value)
+	op, err := secondBody.ReadUint(32)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0xdeadbeef), op)
+}
+
+func TestTonSignTransfer_HighloadV3(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address := createTonWallet(t, b, storage, map[string]interface{}{"wallet_version": "highload-v3", "subwallet_id": 5})
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/ton/svc/sign-transfer")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address": address,
+		"messages": []interface{}{
+			map[string]interface{}{"destination": tonTestDestination, "amount": "1000"},
+		},
+		"query_id": 1<<10 | 7,
+		"deploy":   true,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	_, msg := decodeExternalMessage(t, resp.Data["boc"].(string))
+	assert.True(t, msg.Init.Exists)
+
+	// body: signature(512) + ^msg_inner, подпись по хешу msg_inner
+	body := boc.Cell(msg.Body.Value)
+	signature, err := body.ReadBytes(64)
+	require.NoError(t, err)
+	inner, err := body.NextRef()
+	require.NoError(t, err)
+	innerHash, err := inner.Hash()
+	require.NoError(t, err)
+	seed, err := hex.DecodeString(tonTestSeed)
+	require.NoError(t, err)
+	pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	assert.True(t, ed25519.Verify(pub, innerHash, signature))
+
+	subwalletID, err := inner.ReadUint(32)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), subwalletID)
+	_, err = inner.NextRef()
+	require.NoError(t, err)
+	mode, err := inner.ReadUint(8)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), mode)
+	queryID, err := inner.ReadUint(23)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1<<10|7), queryID)
+	createdAt, err := inner.ReadUint(64)
+	require.NoError(t, err)
+	assert.Equal(t, resp.Data["created_at"], createdAt)
+	timeout, err := inner.ReadUint(22)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3600), timeout)
+}
+
+func TestTonSignTransfer_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address := createTonWallet(t, b, storage, nil)
+	message := map[string]interface{}{"destination": tonTestDestination, "amount": "1000"}
+
+	cases := map[string]map[string]interface{}{
+		"missing seqno":       {"messages": []interface{}{message}},
+		"query_id on v4r2":    {"messages": []interface{}{message}, "seqno": 1, "query_id": 1},
+		"no messages":         {"messages": []interface{}{}, "seqno": 1},
+		"too many messages":   {"messages": []interface{}{message, message, message, message, message}, "seqno": 1},
+		"expired":             {"messages": []interface{}{message}, "seqno": 1, "valid_until": time.Now().Add(-time.Minute).Unix()},
+		"invalid destination": {"messages": []interface{}{map[string]interface{}{"destination": "EQbogus", "amount": "1"}}, "seqno": 1},
+		"negative amount":     {"messages": []interface{}{map[string]interface{}{"destination": tonTestDestination, "amount": "-1"}}, "seqno": 1},
+		"unknown field":       {"messages": []interface{}{map[string]interface{}{"destination": tonTestDestination, "amount": "1", "bogus": 1}}, "seqno": 1},
+		"comment and payload": {"messages": []interface{}{map[string]interface{}{"destination": tonTestDestination, "amount": "1", "comment": "a", "payload": "te6c"}}, "seqno": 1},
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/ton/svc/sign-transfer")
+			req.Storage = storage
+			req.Data = data
+			req.Data["address"] = address
+			_, err := b.HandleRequest(context.Background(), req)
+			require.Error(t, err)
+		})
+	}
+}
@@ -1,16 +1,57 @@
 package ton
 
 import (
-	"crypto/ed25519"
 	"fmt"
-	"github.com/tonkeeper/tongo/wallet"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
 )
 
-// DeriveAddress TonDeriveAddress берёт Ed25519 pub‑key и возвращает bounceable‑friendly TON‑адрес.
-func DeriveAddress(pub ed25519.PublicKey) string {
-	addr, err := wallet.GenerateWalletAddress(pub, wallet.V4R2, nil, 0, nil)
-	if err != nil {
-		panic(fmt.Sprintf("TonDeriveAddress: %v", err))
+// hdPathFormat — SLIP-0010 путь для TON (coin type 607), %d заменяется индексом аккаунта
+const hdPathFormat = "m/44'/607'/%d'"
+
+// defaultWalletVersion — версия контракта кошелька, если она не задана ни в запросе, ни в config/ton
+const defaultWalletVersion = WalletV4R2
+
+// walletVersions — поддерживаемые значения настройки wallet_version
+var walletVersions = map[string]bool{
+	WalletV3R2:       true,
+	WalletV4R2:       true,
+	WalletV5R1:       true,
+	WalletHighloadV3: true,
+}
+
+// parseWalletVersion приводит wallet_version к виду "v4r2"; пустое значение — версия по умолчанию
+func parseWalletVersion(version string) (string, error) {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if version == "" {
+		version = defaultWalletVersion
+	}
+	if !walletVersions[version] {
+		return "", fmt.Errorf("unsupported wallet_version %q", version)
+	}
+	return version, nil
+}
+
+// validateWorkchain допускает basechain (0) и masterchain (-1)
+func validateWorkchain(workchain int32) error {
+	if workchain != 0 && workchain != -1 {
+		return fmt.Errorf("unsupported workchain %d", workchain)
+	}
+	return nil
+}
+
+// validateConfig проверяет config/ton: допустимы wallet_version и workchain
+func validateConfig(cfg *types.ChainConfig) error {
+	if err := backend.CheckConfigFields(cfg, "wallet_version", "workchain"); err != nil {
+		return err
+	}
+	if _, err := parseWalletVersion(cfg.WalletVersion); err != nil {
+		return err
+	}
+	if cfg.Workchain != nil {
+		return validateWorkchain(*cfg.Workchain)
 	}
-	return addr.ToHuman(true, false)
+	return nil
 }
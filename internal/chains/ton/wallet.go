@@ -0,0 +1,187 @@
+package ton
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	"github.com/tonkeeper/tongo/boc"
+	"github.com/tonkeeper/tongo/tlb"
+	"github.com/tonkeeper/tongo/ton"
+	"github.com/tonkeeper/tongo/wallet"
+)
+
+// Wallet contract versions accepted in wallet_version
+const (
+	WalletV3R2       = "v3r2"
+	WalletV4R2       = "v4r2"
+	WalletV5R1       = "v5r1"
+	WalletHighloadV3 = "highload-v3"
+)
+
+// defaultHighloadTimeout — время жизни запроса highload v3 в секундах, входит в StateInit и меняет адрес
+const defaultHighloadTimeout = 3600
+
+// maxHighloadTimeout — timeout хранится в uint22
+const maxHighloadTimeout = 1<<22 - 1
+
+// maxV5SubwalletID — subwallet_number в wallet_id W5 занимает 15 бит
+const maxV5SubwalletID = 1<<15 - 1
+
+// highloadV3CodeHex — код highload-wallet-v3 (code hash 11acad79…8525)
+const highloadV3CodeHex = "b5ee9c7241021001000228000114ff00f4a413f4bcf2c80b01020120020d02014803040078d020d74bc00101c060b0915be101d0d3030171b0915be0fa4030f828c705b39130e0d31f018210ae42e5a4ba9d8040d721d74cf82a01ed55fb04e030020120050a02027306070011adce76a2686b85ffc00201200809001aabb6ed44d0810122d721d70b3f0018aa3bed44d08307d721d70b1f0201200b0c001bb9a6eed44d0810162d721d70b15800e5b8bf2eda2edfb21ab09028409b0ed44d0810120d721f404f404d33fd315d1058e1bf82325a15210b99f326df82305aa0015a112b992306dde923033e2923033e25230800df40f6fa19ed021d721d70a00955f037fdb31e09130e259800df40f6fa19cd001d721d70a00937fdb31e0915be270801f6f2d48308d718d121f900ed44d0d3ffd31ff404f404d33fd315d1f82321a15220b98e12336df82324aa00a112b9926d32de58f82301de541675f910f2a106d0d31fd4d307d30cd309d33fd315d15168baf2a2515abaf2a6f8232aa15250bcf2a304f823bbf2a35304800df40f6fa199d024d721d70a00f2649130e20e01fe5309800df40f6fa18e13d05004d718d20001f264c858cf16cf8301cf168e1030c824cf40cf8384095005a1a514cf40e2f800c94039800df41704c8cbff13cb1ff40012f40012cb3f12cb15c9ed54f80f21d0d30001f265d3020171b0925f03e0fa4001d70b01c000f2a5fa4031fa0031f401fa0031fa00318060d721d300010f0020f265d2000193d431d19130e272b1fb00b585bf03"
+
+// WalletParams describes a wallet contract: everything that goes into its StateInit and thus its address.
+type WalletParams struct {
+	Version     string
+	Workchain   int32
+	SubwalletID uint32
+	Testnet     bool
+	Timeout     uint32
+}
+
+// walletParamsFromKeyPair восстанавливает параметры контракта сохранённого ключа; старые записи — v4r2 на basechain
+func walletParamsFromKeyPair(kp *types.KeyPair) (WalletParams, error) {
+	version, err := parseWalletVersion(kp.WalletVersion)
+	if err != nil {
+		return WalletParams{}, err
+	}
+	p := WalletParams{Version: version, Testnet: kp.Testnet}
+	if kp.Workchain != nil {
+		p.Workchain = *kp.Workchain
+	}
+	p.SubwalletID = defaultSubwalletID(version, p.Workchain)
+	if kp.SubwalletID != nil {
+		p.SubwalletID = *kp.SubwalletID
+	}
+	if version == WalletHighloadV3 {
+		p.Timeout = kp.HighloadTimeout
+		if p.Timeout == 0 {
+			p.Timeout = defaultHighloadTimeout
+		}
+	}
+	return p, nil
+}
+
+// defaultSubwalletID повторяет значения по умолчанию кошельков: 698983191 + workchain для v3/v4, 0 для W5
+func defaultSubwalletID(version string, workchain int32) uint32 {
+	switch version {
+	case WalletV5R1:
+		return 0
+	case WalletHighloadV3:
+		return wallet.DefaultSubWallet
+	default:
+		return uint32(wallet.DefaultSubWallet + int64(workchain))
+	}
+}
+
+func validateSubwalletID(version string, subwalletID uint32) error {
+	if version == WalletV5R1 && subwalletID > maxV5SubwalletID {
+		return fmt.Errorf("subwallet_id of a %s wallet must not exceed %d", WalletV5R1, maxV5SubwalletID)
+	}
+	return nil
+}
+
+// v5WalletID — wallet_id W5: network_global_id XOR {1 bit client, int8 workchain, uint8 version=0, uint15 subwallet}
+func v5WalletID(workchain int32, subwalletID uint32, testnet bool) uint32 {
+	networkGlobalID := int32(wallet.MainnetGlobalID)
+	if testnet {
+		networkGlobalID = wallet.TestnetGlobalID
+	}
+	context := uint32(1)<<31 | uint32(uint8(int8(workchain)))<<23 | subwalletID&maxV5SubwalletID
+	return uint32(networkGlobalID) ^ context
+}
+
+// stateInit собирает StateInit кошелька заданной версии
+func stateInit(pub ed25519.PublicKey, p WalletParams) (*tlb.StateInit, error) {
+	switch p.Version {
+	case WalletV3R2, WalletV4R2:
+		ver := wallet.V3R2
+		if p.Version == WalletV4R2 {
+			ver = wallet.V4R2
+		}
+		subwalletID := p.SubwalletID
+		state, err := wallet.GenerateStateInit(pub, ver, nil, int(p.Workchain), &subwalletID)
+		if err != nil {
+			return nil, err
+		}
+		return &state, nil
+	case WalletV5R1:
+		data := wallet.DataV5R1{
+			IsSignatureAllowed: true,
+			WalletID:           v5WalletID(p.Workchain, p.SubwalletID, p.Testnet),
+			PublicKey:          publicKeyBits(pub),
+		}
+		return buildStateInit(wallet.GetCodeByVer(wallet.V5R1), data)
+	case WalletHighloadV3:
+		code, err := highloadV3Code()
+		if err != nil {
+			return nil, err
+		}
+		return buildStateInit(code, highloadV3Data{
+			PublicKey:   publicKeyBits(pub),
+			SubwalletID: p.SubwalletID,
+			Timeout:     tlb.Uint22(p.Timeout),
+		})
+	default:
+		return nil, fmt.Errorf("unsupported wallet_version %q", p.Version)
+	}
+}
+
+// highloadV3Data — начальное состояние highload v3: пустые old_queries / queries и last_clean_time = 0
+type highloadV3Data struct {
+	PublicKey     tlb.Bits256
+	SubwalletID   uint32
+	OldQueries    tlb.HashmapE[tlb.Uint13, tlb.Any]
+	Queries       tlb.HashmapE[tlb.Uint13, tlb.Any]
+	LastCleanTime uint64
+	Timeout       tlb.Uint22
+}
+
+func publicKeyBits(pub ed25519.PublicKey) tlb.Bits256 {
+	var key tlb.Bits256
+	copy(key[:], pub)
+	return key
+}
+
+func highloadV3Code() (*boc.Cell, error) {
+	raw, err := hex.DecodeString(highloadV3CodeHex)
+	if err != nil {
+		return nil, err
+	}
+	cells, err := boc.DeserializeBoc(raw)
+	if err != nil || len(cells) != 1 {
+		return nil, fmt.Errorf("invalid highload-v3 code: %v", err)
+	}
+	return cells[0], nil
+}
+
+func buildStateInit(code *boc.Cell, data any) (*tlb.StateInit, error) {
+	dataCell := boc.NewCell()
+	if err := tlb.Marshal(dataCell, data); err != nil {
+		return nil, fmt.Errorf("failed to encode wallet data: %w", err)
+	}
+	return &tlb.StateInit{
+		Code: tlb.Maybe[tlb.Ref[boc.Cell]]{Exists: true, Value: tlb.Ref[boc.Cell]{Value: *code}},
+		Data: tlb.Maybe[tlb.Ref[boc.Cell]]{Exists: true, Value: tlb.Ref[boc.Cell]{Value: *dataCell}},
+	}, nil
+}
+
+// DeriveAddress returns the address of the wallet contract described by params: workchain + hash(StateInit).
+// Use ToHuman / ToRaw of the result for the user-friendly and raw forms.
+func DeriveAddress(pub ed25519.PublicKey, p WalletParams) (ton.AccountID, error) {
+	state, err := stateInit(pub, p)
+	if err != nil {
+		return ton.AccountID{}, err
+	}
+	cell := boc.NewCell()
+	if err := tlb.Marshal(cell, state); err != nil {
+		return ton.AccountID{}, fmt.Errorf("failed to encode state init: %w", err)
+	}
+	hash, err := cell.Hash256()
+	if err != nil {
+		return ton.AccountID{}, err
+	}
+	return ton.AccountID{Workchain: p.Workchain, Address: ton.Bits256(hash)}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
 	"github.com/dsshard/vault-crypto-adapters/internal/types"
 	"github.com/ethereum/go-ethereum/log"
 	"regexp"
@@ -64,9 +65,27 @@ func createKeyManager(
 		km = &types.KeyManager{ServiceName: serviceName}
 	}
 
-	// 1) Импорт или генерация приватного ключа
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1) Деривация из мнемоники, импорт или генерация приватного ключа
 	var privateKeyExport *ecdsa.PrivateKey
-	if privateKey != "" {
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privateKey != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormat)
+		derived, err := hd.DeriveSecp256k1(km.Mnemonic, km.Passphrase, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		secpPriv, _ := btcec.PrivKeyFromBytes(derived)
+		privateKeyExport = secpPriv.ToECDSA()
+	} else if privateKey != "" {
 		m := regexp.MustCompile(`^[0-9a-fA-F]{64}$`).FindString(privateKey)
 		if m == "" {
 			return nil, fmt.Errorf("invalid private key")
@@ -100,6 +119,10 @@ func createKeyManager(
 		PublicKey:  hex.EncodeToString(pubBytes),
 		Address:    address,
 	}
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
+	}
 	km.KeyPairs = append(km.KeyPairs, kp)
 
 	// 3) Сохраняем в Vault
@@ -109,11 +132,14 @@ func createKeyManager(
 		return nil, err
 	}
 
+	respData := map[string]interface{}{
+		"service_name": km.ServiceName,
+		"address":      kp.Address,
+		"public_key":   kp.PublicKey,
+	}
+	backend.AddHDResponseData(respData, kp, generatedMnemonic)
+
 	return &logical.Response{
-		Data: map[string]interface{}{
-			"service_name": km.ServiceName,
-			"address":      kp.Address,
-			"public_key":   kp.PublicKey,
-		},
+		Data: respData,
 	}, nil
 }
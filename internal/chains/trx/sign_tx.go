@@ -0,0 +1,119 @@
+package trx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"raw_data_hex": {
+		Type:        framework.TypeString,
+		Description: "Hex protobuf of Transaction.raw (raw_data_hex from TronGrid / TronWeb).",
+		Default:     "",
+	},
+}
+
+// transactionFieldRawData / transactionFieldSignature — поля сообщения Transaction
+const (
+	transactionFieldRawData   = 1
+	transactionFieldSignature = 2
+)
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.TRX),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: signTx,
+			},
+		},
+		HelpSynopsis:    "Sign a TRON transaction from its raw_data protobuf.",
+		HelpDescription: "POST address + raw_data_hex → tx_id (SHA256 of raw_data), signature, signed transaction and the decoded TRX / TRC-20 transfer.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	rawData, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(data.Get("raw_data_hex").(string)), "0x"))
+	if err != nil || len(rawData) == 0 {
+		return nil, fmt.Errorf("invalid raw_data_hex")
+	}
+
+	summary, err := decodeRawData(rawData)
+	if err != nil {
+		return nil, err
+	}
+	// подписываем только транзакции от имени адреса ключа
+	if owner := EncodeAddress(summary.Owner); owner != address {
+		return nil, fmt.Errorf("owner_address %s does not match signing address %s", owner, address)
+	}
+
+	keyPair, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.TRX)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing keyManager %s", address)
+	}
+	privateKey, err := crypto.HexToECDSA(keyPair.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key hex: %w", err)
+	}
+	defer ZeroKey(privateKey)
+
+	// txID = SHA256(raw_data)
+	txID := sha256.Sum256(rawData)
+	sig, err := crypto.Sign(txID[:], privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign failed: %w", err)
+	}
+
+	// Transaction{raw_data, signature} для broadcasthex
+	var signed []byte
+	signed = protowire.AppendTag(signed, transactionFieldRawData, protowire.BytesType)
+	signed = protowire.AppendBytes(signed, rawData)
+	signed = protowire.AppendTag(signed, transactionFieldSignature, protowire.BytesType)
+	signed = protowire.AppendBytes(signed, sig)
+
+	respData := map[string]interface{}{
+		"tx_id":         hex.EncodeToString(txID[:]),
+		"signature":     hex.EncodeToString(sig),
+		"signed_tx_hex": hex.EncodeToString(signed),
+		"contract_type": summary.ContractType,
+		"owner_address": address,
+		"to":            EncodeAddress(summary.To),
+		"amount":        summary.Amount.String(),
+	}
+	if summary.TokenContract != nil {
+		respData["token_contract"] = EncodeAddress(summary.TokenContract)
+		respData["call_value"] = summary.CallValue
+	}
+	if summary.FeeLimit != 0 {
+		respData["fee_limit"] = summary.FeeLimit
+	}
+	if summary.Expiration != 0 {
+		respData["expiration"] = summary.Expiration
+	}
+	return &logical.Response{Data: respData}, nil
+}
@@ -0,0 +1,160 @@
+package trx_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/chains/trx"
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	trxOwner = "TPAYG9ifQaU2T8zNtVqzyzgzKrvawPCwpd"
+	trxTo    = "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"
+	usdtTRC  = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+)
+
+func tronAddress(addr string) []byte {
+	return append([]byte{0x41}, trx.DecodeBase58(addr)...)
+}
+
+func appendBytesField(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendVarintField(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// buildRawData собирает Transaction.raw с одним контрактом
+func buildRawData(contractType uint64, typeURL string, parameter []byte) []byte {
+	var anyMsg []byte
+	anyMsg = appendBytesField(anyMsg, 1, []byte(typeURL))
+	anyMsg = appendBytesField(anyMsg, 2, parameter)
+
+	var contract []byte
+	contract = appendVarintField(contract, 1, contractType)
+	contract = appendBytesField(contract, 2, anyMsg)
+
+	var raw []byte
+	raw = appendBytesField(raw, 1, []byte{0x12, 0x34})
+	raw = appendBytesField(raw, 4, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	raw = appendVarintField(raw, 8, 1700000060000)
+	raw = appendBytesField(raw, 11, contract)
+	raw = appendVarintField(raw, 14, 1700000000000)
+	if contractType == 31 {
+		raw = appendVarintField(raw, 18, 30_000_000)
+	}
+	return raw
+}
+
+func transferRawData(owner string) []byte {
+	return transferRawDataAmount(owner, 1_500_000)
+}
+
+func transferRawDataAmount(owner string, amount uint64) []byte {
+	var param []byte
+	param = appendBytesField(param, 1, tronAddress(owner))
+	param = appendBytesField(param, 2, tronAddress(trxTo))
+	param = appendVarintField(param, 3, amount)
+	return buildRawData(1, "type.googleapis.com/protocol.TransferContract", param)
+}
+
+func trc20RawData(selector string) []byte {
+	return buildRawData(31, "type.googleapis.com/protocol.TriggerSmartContract", trc20Param(selector))
+}
+
+// trc20Param — TriggerSmartContract с вызовом selector(trxTo, 25 USDT)
+func trc20Param(selector string) []byte {
+	call, _ := hex.DecodeString(selector)
+	call = append(call, make([]byte, 12)...)
+	call = append(call, trx.DecodeBase58(trxTo)...)
+	call = append(call, word32(big.NewInt(25_000_000))...)
+
+	var param []byte
+	param = appendBytesField(param, 1, tronAddress(trxOwner))
+	param = appendBytesField(param, 2, tronAddress(usdtTRC))
+	param = appendBytesField(param, 4, call)
+	return param
+}
+
+func word32(v *big.Int) []byte {
+	out := make([]byte, 32)
+	return v.FillBytes(out)
+}
+
+func signTronTx(t *testing.T, rawData []byte) (*logical.Response, error) {
+	t.Helper()
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/trx/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "4c0883a69102937a9280f1222f7c9b6645e1a3c7bf2e5b4cd0bd58d7f9f5d9b7",
+	}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/trx/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":      trxOwner,
+		"raw_data_hex": hex.EncodeToString(rawData),
+	}
+	return b.HandleRequest(context.Background(), req)
+}
+
+func TestTrxSignTx_Transfer(t *testing.T) {
+	rawData := transferRawData(trxOwner)
+	resp, err := signTronTx(t, rawData)
+	require.NoError(t, err)
+
+	txID := sha256.Sum256(rawData)
+	assert.Equal(t, hex.EncodeToString(txID[:]), resp.Data["tx_id"])
+	assert.Equal(t, "TransferContract", resp.Data["contract_type"])
+	assert.Equal(t, trxTo, resp.Data["to"])
+	assert.Equal(t, "1500000", resp.Data["amount"])
+	assert.NotContains(t, resp.Data, "token_contract")
+
+	sig, _ := hex.DecodeString(resp.Data["signature"].(string))
+	pub, err := crypto.SigToPub(txID[:], sig)
+	require.NoError(t, err)
+	assert.Equal(t, trxOwner, trx.DeriveAddress(pub))
+}
+
+func TestTrxSignTx_TRC20(t *testing.T) {
+	resp, err := signTronTx(t, trc20RawData("a9059cbb"))
+	require.NoError(t, err)
+	assert.Equal(t, "TriggerSmartContract", resp.Data["contract_type"])
+	assert.Equal(t, usdtTRC, resp.Data["token_contract"])
+	assert.Equal(t, trxTo, resp.Data["to"])
+	assert.Equal(t, "25000000", resp.Data["amount"])
+	assert.Equal(t, int64(30_000_000), resp.Data["fee_limit"])
+}
+
+func TestTrxSignTx_Rejected(t *testing.T) {
+	cases := map[string][]byte{
+		"foreign owner":     transferRawData(trxTo),
+		"approve call":      trc20RawData("095ea7b3"),
+		"unsupported type":  buildRawData(2, "type.googleapis.com/protocol.TransferAssetContract", nil),
+		"malformed payload": {0x5a, 0xff},
+		"amount overflow":   transferRawDataAmount(trxOwner, 1<<63),
+		"call token value":  buildRawData(31, "type.googleapis.com/protocol.TriggerSmartContract", appendVarintField(trc20Param("a9059cbb"), 5, 1_000_000)),
+		"token id":          buildRawData(31, "type.googleapis.com/protocol.TriggerSmartContract", appendVarintField(trc20Param("a9059cbb"), 6, 1002000)),
+	}
+	for name, rawData := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := signTronTx(t, rawData)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package trx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Номера полей protobuf Tron (core/Tron.proto, core/contract/*.proto)
+const (
+	rawFieldExpiration = 8
+	rawFieldContract   = 11
+	rawFieldTimestamp  = 14
+	rawFieldFeeLimit   = 18
+
+	contractFieldType      = 1
+	contractFieldParameter = 2
+
+	anyFieldValue = 2
+
+	// TransferContract / TriggerSmartContract
+	paramFieldOwnerAddress = 1
+	paramFieldToAddress    = 2 // contract_address у TriggerSmartContract
+	paramFieldAmount       = 3 // call_value у TriggerSmartContract
+	paramFieldData         = 4
+
+	// TriggerSmartContract: TRC-10 токен, переводимый вместе с вызовом
+	paramFieldCallTokenValue = 5
+	paramFieldTokenID        = 6
+)
+
+// Типы контрактов из Transaction.Contract.ContractType
+const (
+	contractTypeTransfer     = 1
+	contractTypeTriggerSmart = 31
+)
+
+// trc20TransferSelector — первые 4 байта keccak256("transfer(address,uint256)")
+var trc20TransferSelector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+// addressVersion — префикс адресов Tron mainnet
+const addressVersion = 0x41
+
+// txSummary — разобранный контракт транзакции, возвращается вместе с подписью
+type txSummary struct {
+	ContractType  string
+	Owner         []byte
+	To            []byte
+	Amount        *big.Int
+	TokenContract []byte
+	CallValue     int64
+	FeeLimit      int64
+	Expiration    int64
+	Timestamp     int64
+}
+
+// decodeRawData разбирает Transaction.raw; поддерживается ровно один контракт:
+// TransferContract или TriggerSmartContract с вызовом TRC-20 transfer
+func decodeRawData(raw []byte) (*txSummary, error) {
+	summary := &txSummary{}
+	var contracts [][]byte
+
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == rawFieldContract && typ == protowire.BytesType:
+			contracts = append(contracts, value)
+		case num == rawFieldExpiration && typ == protowire.VarintType:
+			summary.Expiration = int64(varint)
+		case num == rawFieldTimestamp && typ == protowire.VarintType:
+			summary.Timestamp = int64(varint)
+		case num == rawFieldFeeLimit && typ == protowire.VarintType:
+			summary.FeeLimit = int64(varint)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw_data: %w", err)
+	}
+	if len(contracts) != 1 {
+		return nil, fmt.Errorf("expected exactly one contract, got %d", len(contracts))
+	}
+
+	var contractType uint64
+	var anyValue []byte
+	err = walkFields(contracts[0], func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == contractFieldType && typ == protowire.VarintType:
+			contractType = varint
+		case num == contractFieldParameter && typ == protowire.BytesType:
+			// google.protobuf.Any: нужен только value
+			return walkFields(value, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+				if num == anyFieldValue && typ == protowire.BytesType {
+					anyValue = value
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract: %w", err)
+	}
+
+	var owner, target, callData []byte
+	var amount int64
+	var callTokenValue, tokenID uint64
+	err = walkFields(anyValue, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == paramFieldOwnerAddress && typ == protowire.BytesType:
+			owner = value
+		case num == paramFieldToAddress && typ == protowire.BytesType:
+			target = value
+		case num == paramFieldAmount && typ == protowire.VarintType:
+			if varint > math.MaxInt64 {
+				return fmt.Errorf("amount %d overflows int64", varint)
+			}
+			amount = int64(varint)
+		case num == paramFieldData && typ == protowire.BytesType:
+			callData = value
+		case num == paramFieldCallTokenValue && typ == protowire.VarintType:
+			callTokenValue = varint
+		case num == paramFieldTokenID && typ == protowire.VarintType:
+			tokenID = varint
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract parameter: %w", err)
+	}
+	if err := checkAddress(owner); err != nil {
+		return nil, fmt.Errorf("invalid owner_address: %w", err)
+	}
+	summary.Owner = owner
+
+	switch contractType {
+	case contractTypeTransfer:
+		if err := checkAddress(target); err != nil {
+			return nil, fmt.Errorf("invalid to_address: %w", err)
+		}
+		summary.ContractType = "TransferContract"
+		summary.To = target
+		summary.Amount = big.NewInt(amount)
+	case contractTypeTriggerSmart:
+		if err := checkAddress(target); err != nil {
+			return nil, fmt.Errorf("invalid contract_address: %w", err)
+		}
+		// TRC-10 перевод не попадает в summary, поэтому не подписывается
+		if callTokenValue != 0 || tokenID != 0 {
+			return nil, errors.New("TriggerSmartContract with call_token_value or token_id is not supported")
+		}
+		to, value, err := decodeTRC20Transfer(callData)
+		if err != nil {
+			return nil, err
+		}
+		summary.ContractType = "TriggerSmartContract"
+		summary.TokenContract = target
+		summary.To = to
+		summary.Amount = value
+		summary.CallValue = amount
+	default:
+		return nil, fmt.Errorf("unsupported contract type %d", contractType)
+	}
+	return summary, nil
+}
+
+// decodeTRC20Transfer разбирает ABI-вызов transfer(address,uint256)
+func decodeTRC20Transfer(data []byte) ([]byte, *big.Int, error) {
+	if len(data) != 4+32+32 || !bytes.Equal(data[:4], trc20TransferSelector) {
+		return nil, nil, errors.New("only TRC-20 transfer(address,uint256) calls are supported")
+	}
+	word := data[4:36]
+	// адрес лежит в последних 20 байтах слова, остальное — нули
+	if !bytes.Equal(word[:12], make([]byte, 12)) {
+		return nil, nil, errors.New("invalid TRC-20 recipient")
+	}
+	to := append([]byte{addressVersion}, word[12:]...)
+	return to, new(big.Int).SetBytes(data[36:68]), nil
+}
+
+func checkAddress(addr []byte) error {
+	if len(addr) != 21 || addr[0] != addressVersion {
+		return fmt.Errorf("expected 21-byte address with 0x41 prefix, got %x", addr)
+	}
+	return nil
+}
+
+// walkFields обходит поля protobuf-сообщения; для bytes передаётся value, для varint — varint
+func walkFields(msg []byte, fn func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+
+		var value []byte
+		var varint uint64
+		switch typ {
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(msg)
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(msg)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, msg)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+
+		if err := fn(num, typ, value, varint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
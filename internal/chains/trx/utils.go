@@ -10,6 +10,9 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
+// hdPathFormat — BIP-44 путь для Tron (coin type 195), %d заменяется индексом адреса
+const hdPathFormat = "m/44'/195'/0'/0/%d"
+
 var (
 	errInvalidType = errors.New("invalid input type")
 )
@@ -21,12 +24,16 @@ func DeriveAddress(pub *ecdsa.PublicKey) string {
 	h := sha3.NewLegacyKeccak256()
 	h.Write(raw[1:])
 	addrHash := h.Sum(nil)[12:]
-	// 3) Добавляем версию 0x41 и считаем двойной SHA256 checksum
-	versioned := append([]byte{0x41}, addrHash...)
-	// 4) Двойной SHA256 для контрольной суммы
+	// 3) Добавляем версию 0x41
+	return EncodeAddress(append([]byte{addressVersion}, addrHash...))
+}
+
+// EncodeAddress кодирует 21-байтовый адрес (0x41 || 20 байт) в Base58Check
+func EncodeAddress(versioned []byte) string {
+	// Двойной SHA256 для контрольной суммы
 	cs1 := sha256.Sum256(versioned)
 	cs2 := sha256.Sum256(cs1[:])
-	return base58.Encode(append(versioned, cs2[0:4]...))
+	return base58.Encode(append(append([]byte{}, versioned...), cs2[0:4]...))
 }
 
 // DecodeBase58 декодирует Base58Check‑строку, отбрасывает версию+checksum
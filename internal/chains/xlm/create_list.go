@@ -0,0 +1,117 @@
+package xlm
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+)
+
+// PathCrud registers CRUD operations for Stellar key-managers
+func PathCrud() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathCrud(config.Chain.XLM),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.CreateOperation: &framework.PathOperation{
+				Callback: createKeyManager,
+			},
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: backend.WrapperReadKeyManager(config.Chain.XLM),
+			},
+			logical.DeleteOperation: &framework.PathOperation{
+				Callback: backend.WrapperDeleteKeyManager(config.Chain.XLM),
+			},
+		},
+		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.XLM),
+		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
+		HelpDescription: backend.DefaultHelpDescriptionCreateList,
+		Fields:          backend.DefaultCrudOperations,
+	}
+}
+
+// createKeyManager handles POST /key-managers/xlm
+func createKeyManager(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	serviceName, ok := data.Get("name").(string)
+	if !ok || serviceName == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	privateKey := strings.TrimSpace(data.Get("private_key").(string))
+
+	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.XLM, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		km = &types.KeyManager{ServiceName: serviceName}
+	}
+
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive, decode or generate the 32-byte ed25519 seed
+	var seed []byte
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privateKey != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormat)
+		seed, err = hd.DeriveEd25519(km.Mnemonic, km.Passphrase, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+	} else if privateKey != "" {
+		seed, err = parsePrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		seed = make([]byte, ed25519.SeedSize)
+		if _, err := rand.Read(seed); err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+	}
+	defer zero(seed)
+
+	pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	kp := &types.KeyPair{
+		PrivateKey: hex.EncodeToString(seed),
+		PublicKey:  hex.EncodeToString(pub),
+		Address:    DeriveAddress(pub),
+	}
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
+	}
+	km.KeyPairs = append(km.KeyPairs, kp)
+
+	entry, _ := logical.StorageEntryJSON(config.GetStoragePath(config.Chain.XLM, serviceName), km)
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	respData := map[string]interface{}{
+		"service_name": serviceName,
+		"address":      kp.Address,
+		"public_key":   kp.PublicKey,
+	}
+	backend.AddHDResponseData(respData, kp, generatedMnemonic)
+	return &logical.Response{Data: respData}, nil
+}
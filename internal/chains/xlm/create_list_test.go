@@ -0,0 +1,88 @@
+package xlm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SEP-0005 test 1: m/44'/148'/0'
+const (
+	testMnemonic = "illness spike retreat truth genius clock brain pass fit cave bargain toe"
+	testAddress  = "GDRXE2BQUC3AZNPVFSCEZ76NJ3WWL25FYFK6RGZGIEKWE4SOOHSUJUJ6"
+	testSecret   = "SBGWSG6BTNCKCOB3DIFBGCVMUPQFYPA2G4O34RMTB343OYPXU5DJDVMN"
+	testSeed     = "4d691bc19b44a1383b1a0a130aaca3e05c3c1a371dbe45930ef9b761f7a74691"
+)
+
+func TestXlmCreateAndListKeyManagers(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	// Import an S… secret seed
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xlm/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": testSecret}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, testAddress, resp.Data["address"])
+
+	// The same key as hex
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/xlm/hex")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": "0x" + testSeed}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, testAddress, resp.Data["address"])
+
+	// Generate another key
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/xlm/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^G[A-Z2-7]{55}$`, resp.Data["address"])
+
+	// Read
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/xlm/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	rawPairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, rawPairs, 2)
+
+	for _, bad := range []string{
+		"zz",
+		"0102",
+		testAddress,
+		testSecret[:55] + "O", // broken checksum
+		testSecret[:54],
+	} {
+		req = logical.TestRequest(t, logical.CreateOperation, "key-managers/xlm/bad")
+		req.Storage = storage
+		req.Data = map[string]interface{}{"private_key": bad}
+		_, err = b.HandleRequest(context.Background(), req)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestXlmCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xlm/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"mnemonic": testMnemonic}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, testAddress, resp.Data["address"])
+	assert.Equal(t, "m/44'/148'/0'", resp.Data["derivation_path"])
+
+	// SEP-0005 test 1: m/44'/148'/1'
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/xlm/hd")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/148'/1'", resp.Data["derivation_path"])
+	assert.Equal(t, "GBAW5XGWORWVFE2XTJYDTLDHXTY2Q2MO73HYCGB3XMFMQ562Q2W2GJQX", resp.Data["address"])
+}
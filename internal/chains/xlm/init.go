@@ -0,0 +1,15 @@
+package xlm
+
+import (
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+)
+
+func init() {
+	backend.Register(config.Chain.XLM, backend.Endpoints{
+		Crud:  PathCrud,
+		Sign:  PathSign,
+		Extra: []func() *framework.Path{PathSignTx},
+	})
+}
@@ -0,0 +1,116 @@
+package xlm
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// signatureHintLen — DecoratedSignature.hint: последние 4 байта публичного ключа
+const signatureHintLen = 4
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"envelope_xdr": {
+		Type:        framework.TypeString,
+		Description: "Base64 TransactionEnvelope XDR (v0, v1 or fee bump), as built by stellar-sdk.",
+		Default:     "",
+	},
+	"network_passphrase": {
+		Type:        framework.TypeString,
+		Description: "Network passphrase, e.g. \"Public Global Stellar Network ; September 2015\" or \"Test SDF Network ; September 2015\".",
+		Default:     "",
+	},
+}
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.XLM),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: signTx},
+		},
+		HelpSynopsis:    "Sign a Stellar TransactionEnvelope.",
+		HelpDescription: "POST address, envelope_xdr(base64), network_passphrase → the envelope with a DecoratedSignature over the signature base hash appended, and the transaction hash.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+	passphrase := data.Get("network_passphrase").(string)
+	if passphrase == "" {
+		return nil, fmt.Errorf("network_passphrase is required")
+	}
+	input := strings.TrimSpace(data.Get("envelope_xdr").(string))
+	if input == "" {
+		return nil, fmt.Errorf("envelope_xdr is required")
+	}
+	raw, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return nil, fmt.Errorf("envelope_xdr must be base64 encoded")
+	}
+	env, err := decodeEnvelope(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid envelope_xdr: %w", err)
+	}
+	if len(env.Signatures) >= maxSignatures {
+		return nil, fmt.Errorf("envelope already has %d signatures", maxSignatures)
+	}
+
+	priv, err := loadKey(ctx, req, name, address)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(priv)
+	pub := priv.Public().(ed25519.PublicKey)
+
+	// источник транзакции не проверяется: ключ может быть дополнительным signer'ом аккаунта
+	txHash := env.SignatureBase(passphrase)
+	decorated := decoratedSignature{
+		Hint:      pub[len(pub)-signatureHintLen:],
+		Signature: ed25519.Sign(priv, txHash[:]),
+	}
+	if env.HasSignature(decorated) {
+		return nil, fmt.Errorf("envelope is already signed by %s", address)
+	}
+	signed := env.AppendSignature(raw, decorated)
+
+	respData := map[string]interface{}{
+		"address":        address,
+		"public_key":     hex.EncodeToString(pub),
+		"signature":      base64.StdEncoding.EncodeToString(decorated.Signature),
+		"hint":           hex.EncodeToString(decorated.Hint),
+		"envelope_xdr":   base64.StdEncoding.EncodeToString(signed),
+		"tx_hash":        hex.EncodeToString(txHash[:]),
+		"source_account": env.Source,
+		"fee":            env.Fee,
+		"seq_num":        strconv.FormatInt(env.SeqNum, 10),
+		"operations":     env.Operations,
+		"signatures":     len(env.Signatures) + 1,
+	}
+	if env.Type == envelopeTypeFeeBump {
+		respData["fee_source"] = env.FeeSource
+		respData["fee_bump_fee"] = env.FeeBumpFee
+	}
+	return &logical.Response{Data: respData}, nil
+}
@@ -0,0 +1,194 @@
+package xlm_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testnetPassphrase = "Test SDF Network ; September 2015"
+
+func u32(b []byte, v uint32) []byte { return binary.BigEndian.AppendUint32(b, v) }
+func u64(b []byte, v uint64) []byte { return binary.BigEndian.AppendUint64(b, v) }
+
+// paymentTx собирает Transaction: payment 10 XLM с memo text и PreconditionsV2
+func paymentTx(source ed25519.PublicKey) []byte {
+	tx := u32(nil, 0) // KEY_TYPE_ED25519
+	tx = append(tx, source...)
+	tx = u32(tx, 100)                 // fee
+	tx = u64(tx, 4294967297)          // seqNum
+	tx = u32(tx, 2)                   // PRECOND_V2
+	tx = u64(u64(u32(tx, 1), 0), 1e9) // timeBounds
+	tx = u32(tx, 0)                   // ledgerBounds
+	tx = u32(tx, 0)                   // minSeqNum
+	tx = u64(tx, 0)                   // minSeqAge
+	tx = u32(tx, 0)                   // minSeqLedgerGap
+	tx = u32(tx, 0)                   // extraSigners
+	tx = u32(tx, 1)                   // MEMO_TEXT
+	tx = append(u32(tx, 5), "hello\x00\x00\x00"...)
+
+	tx = u32(tx, 2) // operations
+	// payment: op source — muxed account, native asset
+	tx = u32(tx, 1)
+	tx = u32(tx, 0x100)
+	tx = u64(tx, 42)
+	tx = append(tx, source...)
+	tx = u32(tx, 1)
+	tx = u32(tx, 0)
+	tx = append(tx, make([]byte, 32)...)
+	tx = u32(tx, 0)
+	tx = u64(tx, 100000000)
+	// changeTrust: USDC, issuer
+	tx = u32(tx, 0)
+	tx = u32(tx, 6)
+	tx = append(u32(tx, 1), "USDC"...)
+	tx = u32(tx, 0)
+	tx = append(tx, make([]byte, 32)...)
+	tx = u64(tx, 1<<62)
+	return u32(tx, 0) // ext
+}
+
+func envelope(typ uint32, tx []byte) []byte {
+	return u32(append(u32(nil, typ), tx...), 0)
+}
+
+func signTxRequest(t *testing.T, storage logical.Storage, address string, env []byte) *logical.Request {
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/xlm/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":            address,
+		"envelope_xdr":       base64.StdEncoding.EncodeToString(env),
+		"network_passphrase": testnetPassphrase,
+	}
+	return req
+}
+
+func signatureBase(typ uint32, tx []byte) [32]byte {
+	networkID := sha256.Sum256([]byte(testnetPassphrase))
+	return sha256.Sum256(append(u32(networkID[:], typ), tx...))
+}
+
+func TestXlmSignTx(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createXlmAccount(t, b, storage)
+	tx := paymentTx(pub)
+	env := envelope(2, tx)
+
+	resp, err := b.HandleRequest(context.Background(), signTxRequest(t, storage, address, env))
+	require.NoError(t, err)
+	assert.Equal(t, address, resp.Data["source_account"])
+	assert.Equal(t, uint32(100), resp.Data["fee"])
+	assert.Equal(t, "4294967297", resp.Data["seq_num"])
+	assert.Equal(t, []string{"payment", "changeTrust"}, resp.Data["operations"])
+	assert.Equal(t, 1, resp.Data["signatures"])
+
+	hash := signatureBase(2, tx)
+	assert.Equal(t, hex.EncodeToString(hash[:]), resp.Data["tx_hash"])
+	sig, _ := base64.StdEncoding.DecodeString(resp.Data["signature"].(string))
+	assert.True(t, ed25519.Verify(pub, hash[:], sig))
+	assert.Equal(t, hex.EncodeToString(pub[28:]), resp.Data["hint"])
+
+	// tx || signatures<1>: hint || opaque<64>
+	signed := u32(append(u32(nil, 2), tx...), 1)
+	signed = append(signed, pub[28:]...)
+	signed = append(u32(signed, 64), sig...)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(signed), resp.Data["envelope_xdr"])
+
+	// повторная подпись тем же ключом отклоняется
+	_, err = b.HandleRequest(context.Background(), signTxRequest(t, storage, address, signed))
+	assert.Error(t, err)
+
+	// другой passphrase — другой хэш
+	req := signTxRequest(t, storage, address, env)
+	req.Data["network_passphrase"] = "Public Global Stellar Network ; September 2015"
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.NotEqual(t, hex.EncodeToString(hash[:]), resp.Data["tx_hash"])
+}
+
+func TestXlmSignTx_V0AndFeeBump(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createXlmAccount(t, b, storage)
+	tx := paymentTx(pub)
+
+	// TransactionV0: без типа ключа, TimeBounds* вместо Preconditions
+	v0 := append([]byte{}, pub...)
+	v0 = u32(v0, 100)
+	v0 = u64(v0, 4294967297)
+	v0 = u32(v0, 0)
+	v0 = u32(v0, 0) // MEMO_NONE
+	v0 = u32(v0, 1)
+	v0 = u32(v0, 0)
+	v0 = u32(v0, 11) // bumpSequence
+	v0 = u64(v0, 5)
+	v0 = u32(v0, 0)
+
+	resp, err := b.HandleRequest(context.Background(), signTxRequest(t, storage, address, envelope(0, v0)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bumpSequence"}, resp.Data["operations"])
+	hash := signatureBase(2, append(u32(nil, 0), v0...))
+	assert.Equal(t, hex.EncodeToString(hash[:]), resp.Data["tx_hash"])
+
+	// FeeBumpTransaction с подписанной внутренней транзакцией
+	inner := u32(append(u32(nil, 2), tx...), 1)
+	inner = append(inner, make([]byte, 4)...)
+	inner = append(u32(inner, 64), make([]byte, 64)...)
+	feeBump := u32(nil, 0)
+	feeBump = append(feeBump, pub...)
+	feeBump = u64(feeBump, 400)
+	feeBump = append(feeBump, inner...)
+	feeBump = u32(feeBump, 0)
+
+	resp, err = b.HandleRequest(context.Background(), signTxRequest(t, storage, address, envelope(5, feeBump)))
+	require.NoError(t, err)
+	assert.Equal(t, address, resp.Data["fee_source"])
+	assert.Equal(t, int64(400), resp.Data["fee_bump_fee"])
+	hash = signatureBase(5, feeBump)
+	assert.Equal(t, hex.EncodeToString(hash[:]), resp.Data["tx_hash"])
+	sig, _ := base64.StdEncoding.DecodeString(resp.Data["signature"].(string))
+	assert.True(t, ed25519.Verify(pub, hash[:], sig))
+}
+
+func TestXlmSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	address, pub := createXlmAccount(t, b, storage)
+	tx := paymentTx(pub)
+	env := envelope(2, tx)
+
+	soroban := append([]byte{}, tx[:len(tx)-4]...)
+	soroban = u32(soroban, 1) // ext v1: SorobanTransactionData
+	noOps := append(u32(nil, 0), pub...)
+	noOps = u64(u32(noOps, 100), 1)
+	noOps = u32(u32(u32(u32(noOps, 0), 0), 0), 0) // PRECOND_NONE, MEMO_NONE, operations<0>, ext
+
+	for _, bad := range [][]byte{
+		nil,
+		env[:len(env)-1],
+		append(append([]byte{}, env...), 0, 0, 0, 0),
+		envelope(3, tx),
+		envelope(2, soroban),
+		envelope(2, noOps),
+	} {
+		_, err := b.HandleRequest(context.Background(), signTxRequest(t, storage, address, bad))
+		assert.Error(t, err, hex.EncodeToString(bad))
+	}
+
+	req := signTxRequest(t, storage, address, env)
+	req.Data["envelope_xdr"] = "%%%"
+	_, err := b.HandleRequest(context.Background(), req)
+	assert.Error(t, err)
+
+	req = signTxRequest(t, storage, address, env)
+	req.Data["network_passphrase"] = ""
+	_, err = b.HandleRequest(context.Background(), req)
+	assert.Error(t, err)
+}
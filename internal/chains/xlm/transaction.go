@@ -0,0 +1,878 @@
+package xlm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// EnvelopeType: v0-конверт подписывается как ENVELOPE_TYPE_TX
+const (
+	envelopeTypeTxV0    = 0
+	envelopeTypeTx      = 2
+	envelopeTypeFeeBump = 5
+)
+
+// лимиты из Stellar-transaction.x
+const (
+	maxOperations     = 100
+	maxSignatures     = 20
+	maxSignatureLen   = 64
+	maxMemoTextLen    = 28
+	maxExtraSigners   = 2
+	maxPathLen        = 5
+	maxClaimants      = 10
+	maxHomeDomainLen  = 32
+	maxDataNameLen    = 64
+	maxDataValueLen   = 64
+	maxPayloadLen     = 64
+	maxPredicateDepth = 4
+)
+
+const (
+	keyTypeEd25519      = 0
+	keyTypeMuxedEd25519 = 0x100
+)
+
+// имена OperationType как в stellar-sdk; Soroban (24–26) не разбирается
+var operationNames = map[uint32]string{
+	0:  "createAccount",
+	1:  "payment",
+	2:  "pathPaymentStrictReceive",
+	3:  "manageSellOffer",
+	4:  "createPassiveSellOffer",
+	5:  "setOptions",
+	6:  "changeTrust",
+	7:  "allowTrust",
+	8:  "accountMerge",
+	9:  "inflation",
+	10: "manageData",
+	11: "bumpSequence",
+	12: "manageBuyOffer",
+	13: "pathPaymentStrictSend",
+	14: "createClaimableBalance",
+	15: "claimClaimableBalance",
+	16: "beginSponsoringFutureReserves",
+	17: "endSponsoringFutureReserves",
+	18: "revokeSponsorship",
+	19: "clawback",
+	20: "clawbackClaimableBalance",
+	21: "setTrustLineFlags",
+	22: "liquidityPoolDeposit",
+	23: "liquidityPoolWithdraw",
+}
+
+var errSorobanUnsupported = errors.New("soroban transactions are not supported")
+
+// decoratedSignature — подпись в конверте: последние 4 байта ключа и сама подпись
+type decoratedSignature struct {
+	Hint      []byte
+	Signature []byte
+}
+
+// transactionEnvelope — разобранный TransactionEnvelope и поля для проверки перед подписью
+type transactionEnvelope struct {
+	Type       uint32
+	Tx         []byte // XDR транзакции из конверта (для v0 — TransactionV0)
+	Signatures []decoratedSignature
+	sigsPos    int // смещение длины массива signatures
+
+	Source     string
+	Fee        uint32
+	SeqNum     int64
+	Operations []string
+
+	// только для fee bump
+	FeeSource  string
+	FeeBumpFee int64
+}
+
+var errXDRTruncated = errors.New("truncated XDR")
+
+// xdrReader — последовательное чтение XDR (big-endian, выравнивание по 4 байта)
+type xdrReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *xdrReader) bytes(n int) ([]byte, error) {
+	if n < 0 || len(r.b)-r.pos < n {
+		return nil, errXDRTruncated
+	}
+	out := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return out, nil
+}
+
+func (r *xdrReader) u32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+func (r *xdrReader) u64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+func (r *xdrReader) skip(n int) error {
+	_, err := r.bytes(n)
+	return err
+}
+
+// opaque читает opaque<max> или string<max>; паддинг должен быть нулевым
+func (r *xdrReader) opaque(max uint32) ([]byte, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	if n > max {
+		return nil, fmt.Errorf("opaque length %d exceeds %d", n, max)
+	}
+	out, err := r.bytes(int(n))
+	if err != nil {
+		return nil, err
+	}
+	pad, err := r.bytes(int((4 - n%4) % 4))
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range pad {
+		if b != 0 {
+			return nil, errors.New("non-zero XDR padding")
+		}
+	}
+	return out, nil
+}
+
+// optional читает признак T* (0 или 1)
+func (r *xdrReader) optional() (bool, error) {
+	v, err := r.u32()
+	if err != nil {
+		return false, err
+	}
+	if v > 1 {
+		return false, errors.New("invalid optional flag")
+	}
+	return v == 1, nil
+}
+
+func (r *xdrReader) arrayLen(max uint32) (int, error) {
+	n, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	if n > max {
+		return 0, fmt.Errorf("array length %d exceeds %d", n, max)
+	}
+	return int(n), nil
+}
+
+// ext читает пустой union switch (int v) { case 0: void; }
+func (r *xdrReader) ext() error {
+	v, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if v != 0 {
+		return fmt.Errorf("unsupported extension %d", v)
+	}
+	return nil
+}
+
+// decodeEnvelope разбирает TransactionEnvelope целиком, без хвостовых байт
+func decodeEnvelope(raw []byte) (*transactionEnvelope, error) {
+	r := &xdrReader{b: raw}
+	typ, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	env := &transactionEnvelope{Type: typ}
+	start := r.pos
+	switch typ {
+	case envelopeTypeTxV0:
+		err = r.transactionV0(env)
+	case envelopeTypeTx:
+		err = r.transaction(env)
+	case envelopeTypeFeeBump:
+		err = r.feeBumpTransaction(env)
+	default:
+		return nil, fmt.Errorf("unsupported envelope type %d", typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+	env.Tx = raw[start:r.pos]
+	env.sigsPos = r.pos
+	if env.Signatures, err = r.signatures(); err != nil {
+		return nil, fmt.Errorf("signatures: %w", err)
+	}
+	if r.pos != len(raw) {
+		return nil, errors.New("trailing bytes after TransactionEnvelope")
+	}
+	return env, nil
+}
+
+// SignatureBase returns the hash every signer signs:
+// SHA-256(SHA-256(passphrase) || envelope type || tx).
+func (env *transactionEnvelope) SignatureBase(passphrase string) [32]byte {
+	networkID := sha256.Sum256([]byte(passphrase))
+	buf := append([]byte{}, networkID[:]...)
+	switch env.Type {
+	case envelopeTypeFeeBump:
+		buf = binary.BigEndian.AppendUint32(buf, envelopeTypeFeeBump)
+	case envelopeTypeTxV0:
+		// TransactionV0 → Transaction: sourceAccountEd25519 становится MuxedAccount KEY_TYPE_ED25519
+		buf = binary.BigEndian.AppendUint32(buf, envelopeTypeTx)
+		buf = binary.BigEndian.AppendUint32(buf, keyTypeEd25519)
+	default:
+		buf = binary.BigEndian.AppendUint32(buf, envelopeTypeTx)
+	}
+	return sha256.Sum256(append(buf, env.Tx...))
+}
+
+// AppendSignature returns the envelope XDR with one more DecoratedSignature.
+func (env *transactionEnvelope) AppendSignature(raw []byte, sig decoratedSignature) []byte {
+	out := append([]byte{}, raw[:env.sigsPos]...)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(env.Signatures)+1))
+	out = append(out, raw[env.sigsPos+4:]...)
+	out = append(out, sig.Hint...)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(sig.Signature)))
+	return append(out, sig.Signature...)
+}
+
+// HasSignature сообщает, есть ли уже такая подпись в конверте
+func (env *transactionEnvelope) HasSignature(sig decoratedSignature) bool {
+	for _, s := range env.Signatures {
+		if bytes.Equal(s.Hint, sig.Hint) && bytes.Equal(s.Signature, sig.Signature) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *xdrReader) signatures() ([]decoratedSignature, error) {
+	n, err := r.arrayLen(maxSignatures)
+	if err != nil {
+		return nil, err
+	}
+	sigs := make([]decoratedSignature, 0, n)
+	for i := 0; i < n; i++ {
+		hint, err := r.bytes(4)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := r.opaque(maxSignatureLen)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, decoratedSignature{Hint: hint, Signature: sig})
+	}
+	return sigs, nil
+}
+
+// TransactionV0 { sourceAccountEd25519, fee, seqNum, TimeBounds*, memo, operations, ext }
+func (r *xdrReader) transactionV0(env *transactionEnvelope) error {
+	pub, err := r.bytes(32)
+	if err != nil {
+		return err
+	}
+	env.Source = DeriveAddress(pub)
+	if env.Fee, err = r.u32(); err != nil {
+		return err
+	}
+	seq, err := r.u64()
+	if err != nil {
+		return err
+	}
+	env.SeqNum = int64(seq)
+	present, err := r.optional()
+	if err != nil {
+		return err
+	}
+	if present {
+		if err := r.skip(16); err != nil {
+			return err
+		}
+	}
+	return r.transactionBody(env)
+}
+
+// Transaction { MuxedAccount sourceAccount, fee, seqNum, Preconditions, memo, operations, ext }
+func (r *xdrReader) transaction(env *transactionEnvelope) error {
+	var err error
+	if env.Source, err = r.muxedAccount(); err != nil {
+		return fmt.Errorf("source account: %w", err)
+	}
+	if env.Fee, err = r.u32(); err != nil {
+		return err
+	}
+	seq, err := r.u64()
+	if err != nil {
+		return err
+	}
+	env.SeqNum = int64(seq)
+	if err := r.preconditions(); err != nil {
+		return fmt.Errorf("preconditions: %w", err)
+	}
+	return r.transactionBody(env)
+}
+
+// transactionBody — общий хвост: memo, operations, ext
+func (r *xdrReader) transactionBody(env *transactionEnvelope) error {
+	if err := r.memo(); err != nil {
+		return fmt.Errorf("memo: %w", err)
+	}
+	n, err := r.arrayLen(maxOperations)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("transaction has no operations")
+	}
+	env.Operations = make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		name, err := r.operation()
+		if err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+		env.Operations = append(env.Operations, name)
+	}
+	// ext v1 — SorobanTransactionData
+	v, err := r.u32()
+	if err != nil {
+		return err
+	}
+	switch v {
+	case 0:
+		return nil
+	case 1:
+		return errSorobanUnsupported
+	}
+	return fmt.Errorf("unsupported transaction extension %d", v)
+}
+
+// FeeBumpTransaction { MuxedAccount feeSource, int64 fee, TransactionV1Envelope innerTx, ext }
+func (r *xdrReader) feeBumpTransaction(env *transactionEnvelope) error {
+	var err error
+	if env.FeeSource, err = r.muxedAccount(); err != nil {
+		return fmt.Errorf("fee source: %w", err)
+	}
+	fee, err := r.u64()
+	if err != nil {
+		return err
+	}
+	env.FeeBumpFee = int64(fee)
+	inner, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if inner != envelopeTypeTx {
+		return fmt.Errorf("unsupported inner envelope type %d", inner)
+	}
+	if err := r.transaction(env); err != nil {
+		return fmt.Errorf("inner transaction: %w", err)
+	}
+	if _, err := r.signatures(); err != nil {
+		return fmt.Errorf("inner signatures: %w", err)
+	}
+	return r.ext()
+}
+
+// Preconditions: NONE | TIME(TimeBounds) | V2(PreconditionsV2)
+func (r *xdrReader) preconditions() error {
+	typ, err := r.u32()
+	if err != nil {
+		return err
+	}
+	switch typ {
+	case 0:
+		return nil
+	case 1:
+		return r.skip(16)
+	case 2:
+		// TimeBounds*, LedgerBounds*, SequenceNumber* minSeqNum
+		for _, size := range []int{16, 8, 8} {
+			present, err := r.optional()
+			if err != nil {
+				return err
+			}
+			if present {
+				if err := r.skip(size); err != nil {
+					return err
+				}
+			}
+		}
+		// minSeqAge, minSeqLedgerGap
+		if err := r.skip(8 + 4); err != nil {
+			return err
+		}
+		n, err := r.arrayLen(maxExtraSigners)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if err := r.signerKey(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported precondition type %d", typ)
+}
+
+// Memo: NONE | TEXT(string<28>) | ID(uint64) | HASH | RETURN
+func (r *xdrReader) memo() error {
+	typ, err := r.u32()
+	if err != nil {
+		return err
+	}
+	switch typ {
+	case 0:
+		return nil
+	case 1:
+		_, err := r.opaque(maxMemoTextLen)
+		return err
+	case 2:
+		return r.skip(8)
+	case 3, 4:
+		return r.skip(32)
+	}
+	return fmt.Errorf("unsupported memo type %d", typ)
+}
+
+// muxedAccount возвращает G… или M… адрес
+func (r *xdrReader) muxedAccount() (string, error) {
+	typ, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	switch typ {
+	case keyTypeEd25519:
+		pub, err := r.bytes(32)
+		if err != nil {
+			return "", err
+		}
+		return DeriveAddress(pub), nil
+	case keyTypeMuxedEd25519:
+		id, err := r.u64()
+		if err != nil {
+			return "", err
+		}
+		pub, err := r.bytes(32)
+		if err != nil {
+			return "", err
+		}
+		return muxedAddress(pub, id), nil
+	}
+	return "", fmt.Errorf("unsupported muxed account type %d", typ)
+}
+
+// accountID — PublicKey: только PUBLIC_KEY_TYPE_ED25519
+func (r *xdrReader) accountID() error {
+	typ, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if typ != keyTypeEd25519 {
+		return fmt.Errorf("unsupported public key type %d", typ)
+	}
+	return r.skip(32)
+}
+
+// SignerKey: ED25519 | PRE_AUTH_TX | HASH_X | ED25519_SIGNED_PAYLOAD
+func (r *xdrReader) signerKey() error {
+	typ, err := r.u32()
+	if err != nil {
+		return err
+	}
+	switch typ {
+	case 0, 1, 2:
+		return r.skip(32)
+	case 3:
+		if err := r.skip(32); err != nil {
+			return err
+		}
+		_, err := r.opaque(maxPayloadLen)
+		return err
+	}
+	return fmt.Errorf("unsupported signer key type %d", typ)
+}
+
+// asset: NATIVE | CREDIT_ALPHANUM4 | CREDIT_ALPHANUM12; POOL_SHARE (3) — только где allowPool
+func (r *xdrReader) asset(allowPool bool) (uint32, error) {
+	typ, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case typ == 0:
+		return typ, nil
+	case typ == 1 || typ == 2:
+		code := 4
+		if typ == 2 {
+			code = 12
+		}
+		if err := r.skip(code); err != nil {
+			return 0, err
+		}
+		return typ, r.accountID()
+	case typ == 3 && allowPool:
+		return typ, nil
+	}
+	return 0, fmt.Errorf("unsupported asset type %d", typ)
+}
+
+func (r *xdrReader) assets(max uint32) error {
+	n, err := r.arrayLen(max)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeTrustAsset: Asset | POOL_SHARE(LiquidityPoolParameters)
+func (r *xdrReader) changeTrustAsset() error {
+	typ, err := r.asset(true)
+	if err != nil || typ != 3 {
+		return err
+	}
+	// LIQUIDITY_POOL_CONSTANT_PRODUCT { assetA, assetB, int32 fee }
+	poolType, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if poolType != 0 {
+		return fmt.Errorf("unsupported liquidity pool type %d", poolType)
+	}
+	if _, err := r.asset(false); err != nil {
+		return err
+	}
+	if _, err := r.asset(false); err != nil {
+		return err
+	}
+	return r.skip(4)
+}
+
+// trustLineAsset: Asset | POOL_SHARE(PoolID)
+func (r *xdrReader) trustLineAsset() error {
+	typ, err := r.asset(true)
+	if err != nil || typ != 3 {
+		return err
+	}
+	return r.skip(32)
+}
+
+// claimableBalanceID: CLAIMABLE_BALANCE_ID_TYPE_V0(Hash)
+func (r *xdrReader) claimableBalanceID() error {
+	typ, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if typ != 0 {
+		return fmt.Errorf("unsupported claimable balance id type %d", typ)
+	}
+	return r.skip(32)
+}
+
+// claimPredicate: UNCONDITIONAL | AND<2> | OR<2> | NOT* | BEFORE_ABSOLUTE_TIME | BEFORE_RELATIVE_TIME
+func (r *xdrReader) claimPredicate(depth int) error {
+	if depth > maxPredicateDepth {
+		return errors.New("claim predicate nesting is too deep")
+	}
+	typ, err := r.u32()
+	if err != nil {
+		return err
+	}
+	switch typ {
+	case 0:
+		return nil
+	case 1, 2:
+		n, err := r.arrayLen(2)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if err := r.claimPredicate(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case 3:
+		present, err := r.optional()
+		if err != nil || !present {
+			return err
+		}
+		return r.claimPredicate(depth + 1)
+	case 4, 5:
+		return r.skip(8)
+	}
+	return fmt.Errorf("unsupported claim predicate type %d", typ)
+}
+
+// ledgerKey для REVOKE_SPONSORSHIP: ACCOUNT | TRUSTLINE | OFFER | DATA | CLAIMABLE_BALANCE
+func (r *xdrReader) ledgerKey() error {
+	typ, err := r.u32()
+	if err != nil {
+		return err
+	}
+	switch typ {
+	case 0:
+		return r.accountID()
+	case 1:
+		if err := r.accountID(); err != nil {
+			return err
+		}
+		return r.trustLineAsset()
+	case 2:
+		if err := r.accountID(); err != nil {
+			return err
+		}
+		return r.skip(8)
+	case 3:
+		if err := r.accountID(); err != nil {
+			return err
+		}
+		_, err := r.opaque(maxDataNameLen)
+		return err
+	case 4:
+		return r.claimableBalanceID()
+	}
+	return fmt.Errorf("unsupported ledger key type %d", typ)
+}
+
+// skipOptional пропускает T*, где T — поле фиксированного размера
+func (r *xdrReader) skipOptional(size int) error {
+	present, err := r.optional()
+	if err != nil || !present {
+		return err
+	}
+	return r.skip(size)
+}
+
+// operation { MuxedAccount* sourceAccount, body } → имя операции
+func (r *xdrReader) operation() (string, error) {
+	present, err := r.optional()
+	if err != nil {
+		return "", err
+	}
+	if present {
+		if _, err := r.muxedAccount(); err != nil {
+			return "", fmt.Errorf("source account: %w", err)
+		}
+	}
+	typ, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	name, ok := operationNames[typ]
+	if !ok {
+		if typ >= 24 && typ <= 26 {
+			return "", errSorobanUnsupported
+		}
+		return "", fmt.Errorf("unsupported operation type %d", typ)
+	}
+	if err := r.operationBody(typ); err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return name, nil
+}
+
+func (r *xdrReader) operationBody(typ uint32) error {
+	switch typ {
+	case 0: // destination, startingBalance
+		if err := r.accountID(); err != nil {
+			return err
+		}
+		return r.skip(8)
+	case 1: // destination, asset, amount
+		if _, err := r.muxedAccount(); err != nil {
+			return err
+		}
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+		return r.skip(8)
+	case 2, 13: // sendAsset, sendMax/sendAmount, destination, destAsset, destAmount/destMin, path<5>
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+		if err := r.skip(8); err != nil {
+			return err
+		}
+		if _, err := r.muxedAccount(); err != nil {
+			return err
+		}
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+		if err := r.skip(8); err != nil {
+			return err
+		}
+		return r.assets(maxPathLen)
+	case 3, 4, 12: // selling, buying, amount, price[, offerID]
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+		size := 8 + 8
+		if typ != 4 {
+			size += 8
+		}
+		return r.skip(size)
+	case 5: // setOptions
+		present, err := r.optional()
+		if err != nil {
+			return err
+		}
+		if present {
+			if err := r.accountID(); err != nil {
+				return err
+			}
+		}
+		// clearFlags, setFlags, masterWeight, low/med/highThreshold
+		for i := 0; i < 6; i++ {
+			if err := r.skipOptional(4); err != nil {
+				return err
+			}
+		}
+		if present, err = r.optional(); err != nil {
+			return err
+		}
+		if present {
+			if _, err := r.opaque(maxHomeDomainLen); err != nil {
+				return err
+			}
+		}
+		if present, err = r.optional(); err != nil || !present {
+			return err
+		}
+		if err := r.signerKey(); err != nil {
+			return err
+		}
+		return r.skip(4)
+	case 6: // line, limit
+		if err := r.changeTrustAsset(); err != nil {
+			return err
+		}
+		return r.skip(8)
+	case 7: // trustor, AssetCode, authorize
+		if err := r.accountID(); err != nil {
+			return err
+		}
+		code, err := r.u32()
+		if err != nil {
+			return err
+		}
+		switch code {
+		case 1:
+			err = r.skip(4)
+		case 2:
+			err = r.skip(12)
+		default:
+			return fmt.Errorf("unsupported asset code type %d", code)
+		}
+		if err != nil {
+			return err
+		}
+		return r.skip(4)
+	case 8: // destination
+		_, err := r.muxedAccount()
+		return err
+	case 9, 17:
+		return nil
+	case 10: // dataName, DataValue*
+		if _, err := r.opaque(maxDataNameLen); err != nil {
+			return err
+		}
+		present, err := r.optional()
+		if err != nil || !present {
+			return err
+		}
+		_, err = r.opaque(maxDataValueLen)
+		return err
+	case 11: // bumpTo
+		return r.skip(8)
+	case 14: // asset, amount, claimants<10>
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+		if err := r.skip(8); err != nil {
+			return err
+		}
+		n, err := r.arrayLen(maxClaimants)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			v, err := r.u32()
+			if err != nil {
+				return err
+			}
+			if v != 0 {
+				return fmt.Errorf("unsupported claimant type %d", v)
+			}
+			if err := r.accountID(); err != nil {
+				return err
+			}
+			if err := r.claimPredicate(0); err != nil {
+				return err
+			}
+		}
+		return nil
+	case 15, 20: // balanceID
+		return r.claimableBalanceID()
+	case 16: // sponsoredID
+		return r.accountID()
+	case 18: // LedgerKey | { accountID, SignerKey }
+		v, err := r.u32()
+		if err != nil {
+			return err
+		}
+		switch v {
+		case 0:
+			return r.ledgerKey()
+		case 1:
+			if err := r.accountID(); err != nil {
+				return err
+			}
+			return r.signerKey()
+		}
+		return fmt.Errorf("unsupported revoke sponsorship type %d", v)
+	case 19: // asset, from, amount
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+		if _, err := r.muxedAccount(); err != nil {
+			return err
+		}
+		return r.skip(8)
+	case 21: // trustor, asset, clearFlags, setFlags
+		if err := r.accountID(); err != nil {
+			return err
+		}
+		if _, err := r.asset(false); err != nil {
+			return err
+		}
+		return r.skip(8)
+	case 22: // poolID, maxAmountA, maxAmountB, minPrice, maxPrice
+		return r.skip(32 + 8 + 8 + 8 + 8)
+	case 23: // poolID, amount, minAmountA, minAmountB
+		return r.skip(32 + 8 + 8 + 8)
+	}
+	return fmt.Errorf("unsupported operation type %d", typ)
+}
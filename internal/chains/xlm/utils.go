@@ -0,0 +1,116 @@
+package xlm
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// hdPathFormat — SEP-0005 путь Stellar, %d заменяется индексом аккаунта
+const hdPathFormat = "m/44'/148'/%d'"
+
+// StrKey version bytes: G… (account), S… (seed), M… (muxed account)
+const (
+	versionAccountID    byte = 6 << 3
+	versionSeed         byte = 18 << 3
+	versionMuxedAccount byte = 12 << 3
+)
+
+var errInvalidStrKey = errors.New("invalid strkey")
+
+var strKeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EncodeStrKey encodes payload as a StrKey: base32(version || payload || CRC16-XModem LE).
+func EncodeStrKey(version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+	data = binary.LittleEndian.AppendUint16(data, crc16(data))
+	return strKeyEncoding.EncodeToString(data)
+}
+
+// DecodeStrKey checks the version and the checksum and returns the payload.
+func DecodeStrKey(version byte, s string) ([]byte, error) {
+	data, err := strKeyEncoding.DecodeString(s)
+	if err != nil || len(data) < 3 || data[0] != version {
+		return nil, errInvalidStrKey
+	}
+	body, checksum := data[:len(data)-2], data[len(data)-2:]
+	if binary.LittleEndian.Uint16(checksum) != crc16(body) {
+		return nil, errInvalidStrKey
+	}
+	// base32 без лишних бит: повторное кодирование должно дать ту же строку
+	if strKeyEncoding.EncodeToString(data) != s {
+		return nil, errInvalidStrKey
+	}
+	return body[1:], nil
+}
+
+// crc16 — CRC16-XModem (полином 0x1021, начальное значение 0)
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// DeriveAddress returns the G… account ID of an ed25519 public key.
+func DeriveAddress(pub []byte) string {
+	return EncodeStrKey(versionAccountID, pub)
+}
+
+// parsePrivateKey принимает 32-байтовый seed в hex или StrKey S…
+func parsePrivateKey(input string) ([]byte, error) {
+	if strings.HasPrefix(input, "S") {
+		seed, err := DecodeStrKey(versionSeed, input)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid private key: malformed S… secret seed")
+		}
+		return seed, nil
+	}
+	seed, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid private key: expected a 32-byte hex ed25519 seed or an S… secret seed")
+	}
+	return seed, nil
+}
+
+// muxedAddress — M… адрес muxed-аккаунта: ed25519 || id (big-endian)
+func muxedAddress(pub []byte, id uint64) string {
+	return EncodeStrKey(versionMuxedAccount, binary.BigEndian.AppendUint64(append([]byte{}, pub...), id))
+}
+
+// loadKey достаёт ключ сервиса по адресу
+func loadKey(ctx context.Context, req *logical.Request, name, address string) (ed25519.PrivateKey, error) {
+	kp, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.XLM)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing key for address %s: %w", address, err)
+	}
+	seed, err := hex.DecodeString(kp.PrivateKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("stored private key is not a valid seed")
+	}
+	defer zero(seed)
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -8,13 +8,12 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/btcsuite/btcd/btcec/v2"
-
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
 
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
 	"github.com/dsshard/vault-crypto-adapters/internal/types"
 )
 
@@ -26,7 +25,7 @@ func PathCrud() *framework.Path {
 				Callback: createKeyManager,
 			},
 			logical.ReadOperation: &framework.PathOperation{
-				Callback: backend.WrapperReadKeyManager(config.Chain.XRP),
+				Callback: readKeyManager,
 			},
 			logical.DeleteOperation: &framework.PathOperation{
 				Callback: backend.WrapperDeleteKeyManager(config.Chain.XRP),
@@ -35,10 +34,46 @@ func PathCrud() *framework.Path {
 		ExistenceCheck:  backend.KeyManagerExistenceCheck(config.Chain.XRP),
 		HelpSynopsis:    backend.DefaultHelpHelpSynopsisCreateList,
 		HelpDescription: backend.DefaultHelpDescriptionCreateList,
-		Fields:          backend.DefaultCrudOperations,
+		Fields:          createFields,
 	}
 }
 
+var createFields = backend.CrudFields(map[string]*framework.FieldSchema{
+	"key_type": {
+		Type:        framework.TypeString,
+		Description: "(Optional) Account key type: secp256k1 (default) or ed25519. An sEd… family seed implies ed25519",
+	},
+	"private_key": {
+		Type:        framework.TypeString,
+		Description: "(Optional, default random key) Hex private key or XRPL family seed (s…)",
+		Default:     "",
+	},
+	"destination_tag": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) Destination tag; the response then includes mainnet and testnet X-addresses (XLS-5d)",
+	},
+})
+
+// readKeyManager дополняет пары X-адресами, если передан destination_tag
+func readKeyManager(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	tag, err := destinationTag(data)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := backend.WrapperReadKeyManager(config.Chain.XRP)(ctx, req, data)
+	if err != nil || resp == nil || resp.Data == nil || tag == nil {
+		return resp, err
+	}
+	pairs, _ := resp.Data["key_pairs"].([]map[string]interface{})
+	for _, pair := range pairs {
+		if err := addXAddresses(pair, pair["address"].(string), tag); err != nil {
+			return nil, err
+		}
+	}
+	resp.Data["destination_tag"] = *tag
+	return resp, nil
+}
+
 func createKeyManager(
 	ctx context.Context,
 	req *logical.Request,
@@ -54,6 +89,14 @@ func createKeyManager(
 		return nil, fmt.Errorf("private_key must be a string")
 	}
 	privHex = strings.TrimSpace(privHex)
+	kt, err := parseKeyType(data.Get("key_type").(string))
+	if err != nil {
+		return nil, err
+	}
+	tag, err := destinationTag(data)
+	if err != nil {
+		return nil, err
+	}
 
 	// 2) Retrieve or init KeyManager
 	km, err := backend.RetrieveKeyManager(ctx, req, config.Chain.XRP, serviceName)
@@ -64,9 +107,52 @@ func createKeyManager(
 		km = &types.KeyManager{ServiceName: serviceName}
 	}
 
-	// 3) Decode or generate 32‑byte seed
+	generatedMnemonic, err := backend.SetupMnemonic(km, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3) Derive, decode or generate 32‑byte seed (secp256k1 d или ed25519 seed)
 	var seed [32]byte
-	if privHex != "" {
+	var derivationPath string
+	var derivationIndex uint32
+	if km.Mnemonic != "" {
+		if privHex != "" {
+			return nil, backend.ErrPrivateKeyWithMnemonic
+		}
+		var derived []byte
+		if kt == KeyTypeEd25519 {
+			derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormatEd25519)
+			derived, err = hd.DeriveEd25519(km.Mnemonic, km.Passphrase, derivationPath)
+		} else {
+			derivationPath, derivationIndex = backend.NextDerivationPath(km, hdPathFormat)
+			derived, err = hd.DeriveSecp256k1(km.Mnemonic, km.Passphrase, derivationPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+		copy(seed[:], derived)
+		zeroSeed(derived)
+	} else if strings.HasPrefix(privHex, "s") {
+		entropy, seedKeyType, err := decodeFamilySeed(privHex)
+		if err != nil {
+			return nil, err
+		}
+		if seedKeyType != "" {
+			// sEd… однозначно задаёт ed25519
+			if keyTypeSet(data) && kt != seedKeyType {
+				return nil, fmt.Errorf("family seed is for %s keys, key_type is %s", seedKeyType, kt)
+			}
+			kt = seedKeyType
+		}
+		derived, err := privateKeyFromFamilySeed(entropy, kt)
+		zeroSeed(entropy)
+		if err != nil {
+			return nil, err
+		}
+		copy(seed[:], derived)
+		zeroSeed(derived)
+	} else if privHex != "" {
 		bs, err := hex.DecodeString(strings.TrimPrefix(privHex, "0x"))
 		if err != nil || len(bs) != len(seed) {
 			return nil, fmt.Errorf("invalid private key")
@@ -79,18 +165,22 @@ func createKeyManager(
 	}
 	defer zeroSeed(seed[:])
 
-	// 4) Build secp256k1 keypair
-	priv, _ := btcec.PrivKeyFromBytes(seed[:])
-	pub := priv.PubKey()
+	// 4) Build keypair
+	pub := publicKey(seed[:], kt)
 
 	// 5) Derive XRP‑address
-	addr := DeriveClassicXRPAddress(pub.SerializeCompressed())
+	addr := DeriveClassicXRPAddress(pub)
 
 	// 6) Save KeyPair
 	kp := &types.KeyPair{
 		PrivateKey: hex.EncodeToString(seed[:]),
-		PublicKey:  hex.EncodeToString(pub.SerializeCompressed()),
+		PublicKey:  hex.EncodeToString(pub),
 		Address:    addr,
+		KeyType:    kt,
+	}
+	if derivationPath != "" {
+		kp.DerivationPath = derivationPath
+		kp.DerivationIndex = &derivationIndex
 	}
 	km.KeyPairs = append(km.KeyPairs, kp)
 
@@ -102,13 +192,27 @@ func createKeyManager(
 	}
 
 	// 8) Response
-	return &logical.Response{
-		Data: map[string]interface{}{
-			"service_name": serviceName,
-			"address":      addr,
-			"public_key":   kp.PublicKey,
-		},
-	}, nil
+	respData := map[string]interface{}{
+		"service_name": serviceName,
+		"address":      addr,
+		"public_key":   kp.PublicKey,
+		"key_type":     kt,
+	}
+	backend.AddHDResponseData(respData, kp, generatedMnemonic)
+	if tag != nil {
+		if err := addXAddresses(respData, addr, tag); err != nil {
+			return nil, err
+		}
+		respData["destination_tag"] = *tag
+	}
+
+	return &logical.Response{Data: respData}, nil
+}
+
+// keyTypeSet — key_type передан в запросе явно
+func keyTypeSet(data *framework.FieldData) bool {
+	_, ok := data.GetOk("key_type")
+	return ok
 }
 
 // zeroSeed обнуляет срез байт seed.
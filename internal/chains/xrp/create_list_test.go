@@ -82,3 +82,108 @@ func TestXrpCreateAndListKeyManagers_InvalidPrivKey(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid private key")
 }
+
+func TestXrpCreateKeyManagers_Mnemonic(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3", resp.Data["address"])
+	assert.Equal(t, "m/44'/144'/0'/0/0", resp.Data["derivation_path"])
+}
+
+func TestXrpCreateKeyManagers_FamilySeed(t *testing.T) {
+	cases := []struct {
+		name      string
+		seed      string
+		keyType   string
+		address   string
+		publicKey string
+	}{
+		{
+			name:      "secp256k1",
+			seed:      "sp5fghtJtpUorTwvof1NpDXAzNwf5",
+			keyType:   "secp256k1",
+			address:   "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1",
+			publicKey: "030d58eb48b4420b1f7b9df55087e0e29fef0e8468f9a6825b01ca2c361042d435",
+		},
+		{
+			name:      "ed25519",
+			seed:      "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r",
+			keyType:   "ed25519",
+			address:   "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD",
+			publicKey: "ed01fa53fa5a7e77798f882ece20b1abc00bb358a9e55a202d0d0676bd0ce37a63",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, storage := test.NewTestBackend(t)
+			req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/svc")
+			req.Storage = storage
+			req.Data = map[string]interface{}{"private_key": tc.seed}
+			resp, err := b.HandleRequest(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.address, resp.Data["address"])
+			assert.Equal(t, tc.publicKey, resp.Data["public_key"])
+			assert.Equal(t, tc.keyType, resp.Data["key_type"])
+		})
+	}
+}
+
+func TestXrpCreateKeyManagers_Ed25519(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"key_type": "ed25519"}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Regexp(t, `^r[1-9A-HJ-NP-Za-km-z]{25,34}$`, resp.Data["address"])
+	assert.Regexp(t, `^ed[0-9a-f]{64}$`, resp.Data["public_key"])
+
+	// an sEd… seed cannot be imported as secp256k1
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r", "key_type": "secp256k1"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+
+	// короткий seed не должен ронять обработчик
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": "sprrr"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+
+	req = logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"key_type": "sr25519"}
+	_, err = b.HandleRequest(context.Background(), req)
+	require.Error(t, err)
+
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pairs := resp.Data["key_pairs"].([]map[string]interface{})
+	require.Len(t, pairs, 1)
+	assert.Equal(t, "ed25519", pairs[0]["key_type"])
+}
+
+func TestXrpCreateKeyManagers_MnemonicEd25519(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/hd")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"key_type": "ed25519",
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/144'/0'/0'/0'", resp.Data["derivation_path"])
+	assert.Regexp(t, `^ed[0-9a-f]{64}$`, resp.Data["public_key"])
+}
@@ -3,11 +3,13 @@ package xrp
 import (
 	"github.com/dsshard/vault-crypto-adapters/internal/backend"
 	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
 )
 
 func init() {
 	backend.Register(config.Chain.XRP, backend.Endpoints{
-		Crud: PathCrud,
-		Sign: PathSign,
+		Crud:  PathCrud,
+		Sign:  PathSign,
+		Extra: []func() *framework.Path{PathSignTx, PathXAddress},
 	})
 }
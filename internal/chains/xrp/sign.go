@@ -2,6 +2,7 @@ package xrp
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/hex"
 	"fmt"
 	"github.com/btcsuite/btcd/btcec/v2"
@@ -20,7 +21,7 @@ func PathSign() *framework.Path {
 			logical.UpdateOperation: &framework.PathOperation{Callback: signTransaction},
 		},
 		HelpSynopsis:    "Sign an XRP transaction blob",
-		HelpDescription: "POST serviceName + address + txBlob(hex) → signature(hex)",
+		HelpDescription: "POST serviceName + address + txBlob(hex) → signature(hex): DER secp256k1 over SHA-512Half or raw ed25519 over the blob",
 		Fields:          backend.DefaultSignOperation,
 	}
 }
@@ -47,12 +48,14 @@ func signTransaction(
 
 	// 2) Find the requested private key (32‑byte hex seed → secp256k1 d)
 	var privBytes []byte
+	var kt string
 	for _, kp := range km.KeyPairs {
 		if kp.Address == address {
 			privBytes, err = hex.DecodeString(kp.PrivateKey)
 			if err != nil {
 				return nil, fmt.Errorf("stored privateKey is invalid hex: %w", err)
 			}
+			kt = keyType(kp.KeyType)
 			break
 		}
 	}
@@ -66,6 +69,17 @@ func signTransaction(
 		return nil, fmt.Errorf("invalid txBlob hex: %w", err)
 	}
 
+	// ed25519 подписывает сами байты, без предварительного хеширования
+	if kt == KeyTypeEd25519 {
+		sig := ed25519.Sign(ed25519.NewKeyFromSeed(privBytes), txBytes)
+		zeroSeed(privBytes)
+		return &logical.Response{
+			Data: map[string]interface{}{
+				"signature": hex.EncodeToString(sig),
+			},
+		}, nil
+	}
+
 	// XRP uses the first 32 bytes of SHA-512Half(txBytes)
 	hash := ripplecrypto.Sha512Half(txBytes)
 
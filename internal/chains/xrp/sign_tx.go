@@ -0,0 +1,142 @@
+package xrp
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/backend"
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/rubblelabs/ripple/data"
+)
+
+var signTxFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "The address that belongs to a private key in the key-manager.",
+	},
+	"tx_json": {
+		Type:        framework.TypeMap,
+		Description: "XRPL transaction JSON (Payment, TrustSet, OfferCreate, ...) with Account, Fee and Sequence set.",
+	},
+}
+
+func PathSignTx() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathSignTx(config.Chain.XRP),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: signTx,
+			},
+		},
+		HelpSynopsis:    "Sign an XRPL transaction JSON.",
+		HelpDescription: "POST address + tx_json → tx_blob (canonical binary with SigningPubKey and TxnSignature) and hash, ready for submit.",
+		Fields:          signTxFields,
+	}
+}
+
+func signTx(
+	ctx context.Context,
+	req *logical.Request,
+	fields *framework.FieldData,
+) (*logical.Response, error) {
+	name, address, err := backend.GetAddressParamsFromData(fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request data: %w", err)
+	}
+
+	rawTx, ok := fields.Get("tx_json").(map[string]interface{})
+	if !ok || len(rawTx) == 0 {
+		return nil, fmt.Errorf("tx_json must be a JSON object")
+	}
+	tx, err := parseTransaction(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	// подписываем только транзакции от имени адреса ключа
+	if account := tx.GetBase().Account.String(); account != address {
+		return nil, fmt.Errorf("Account %s does not match signing address %s", account, address)
+	}
+
+	keyPair, err := backend.GetKeyPairByAddressAndChain(ctx, req, name, address, config.Chain.XRP)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signing keyManager %s", address)
+	}
+	key, err := newSigningKey(keyPair)
+	if err != nil {
+		return nil, err
+	}
+	defer key.zero()
+
+	// SigningPubKey → "STX\0" || tx → TxnSignature: secp256k1 подписывает SHA512Half с low-S (fully canonical), ed25519 — само сообщение
+	if err := data.Sign(tx, key, nil); err != nil {
+		return nil, fmt.Errorf("sign failed: %w", err)
+	}
+	hash, blob, err := data.Raw(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"tx_blob":          strings.ToUpper(hex.EncodeToString(blob)),
+			"hash":             hash.String(),
+			"signature":        strings.ToUpper(hex.EncodeToString(tx.GetSignature().Bytes())),
+			"signing_pub_key":  strings.ToUpper(hex.EncodeToString(tx.GetPublicKey().Bytes())),
+			"transaction_type": tx.GetType(),
+			"account":          address,
+		},
+	}, nil
+}
+
+// parseTransaction собирает транзакцию нужного типа; неизвестные поля — ошибка, чтобы не подписать не то, что прислали
+func parseTransaction(rawTx map[string]interface{}) (data.Transaction, error) {
+	txTypeName, _ := rawTx["TransactionType"].(string)
+	var txType data.TransactionType
+	if err := txType.UnmarshalText([]byte(txTypeName)); err != nil {
+		return nil, fmt.Errorf("invalid tx_json: %w", err)
+	}
+	if int(txType) >= len(data.TxFactory) || data.TxFactory[txType] == nil {
+		return nil, fmt.Errorf("invalid tx_json: unsupported TransactionType %s", txTypeName)
+	}
+	for _, field := range []string{"SigningPubKey", "TxnSignature", "Signers"} {
+		if _, ok := rawTx[field]; ok {
+			return nil, fmt.Errorf("invalid tx_json: %s must not be set", field)
+		}
+	}
+
+	encoded, err := json.Marshal(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tx_json: %w", err)
+	}
+	tx := data.TxFactory[txType]()
+	if err := decodeStrict(encoded, tx); err != nil {
+		return nil, fmt.Errorf("invalid tx_json: %w", err)
+	}
+
+	for _, field := range []string{"Account", "Fee", "Sequence"} {
+		if _, ok := rawTx[field]; !ok {
+			return nil, fmt.Errorf("invalid tx_json: %s is required", field)
+		}
+	}
+	return tx, nil
+}
+
+// decodeStrict декодирует JSON без неизвестных полей; rubblelabs паникует на коротких
+// base58-адресах (Account, Destination, Issuer…), паника превращается в ошибку
+func decodeStrict(encoded []byte, tx data.Transaction) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed field value")
+		}
+	}()
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.DisallowUnknownFields()
+	return dec.Decode(tx)
+}
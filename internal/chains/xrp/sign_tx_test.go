@@ -0,0 +1,175 @@
+package xrp_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/rubblelabs/ripple/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const xrpTestAddress = "rh8Xyr355XDm5PCMzD1qWcjd5b5GqLpdqm"
+
+func createXrpAccount(t *testing.T, b logical.Backend, storage logical.Storage) {
+	t.Helper()
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key": "90dc3e2382d825f290148356dbbe315135dc0fe60bb17030edd2ea6127f938d5",
+	}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+}
+
+func TestXrpSignTx(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"payment": {
+			"TransactionType": "Payment",
+			"Account":         xrpTestAddress,
+			"Destination":     "rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3",
+			"Amount":          "1000000",
+			"Fee":             "12",
+			"Sequence":        7,
+			"DestinationTag":  42,
+		},
+		"trust set": {
+			"TransactionType": "TrustSet",
+			"Account":         xrpTestAddress,
+			"LimitAmount": map[string]interface{}{
+				"currency": "USD",
+				"issuer":   "rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3",
+				"value":    "100",
+			},
+			"Fee":      "12",
+			"Sequence": 8,
+		},
+	}
+
+	for name, txJSON := range cases {
+		t.Run(name, func(t *testing.T) {
+			b, storage := test.NewTestBackend(t)
+			createXrpAccount(t, b, storage)
+
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/xrp/svc/sign-tx")
+			req.Storage = storage
+			req.Data = map[string]interface{}{
+				"address": xrpTestAddress,
+				"tx_json": txJSON,
+			}
+			resp, err := b.HandleRequest(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, txJSON["TransactionType"], resp.Data["transaction_type"])
+
+			blob, err := hex.DecodeString(resp.Data["tx_blob"].(string))
+			require.NoError(t, err)
+			tx, err := data.ReadTransaction(bytes.NewReader(blob))
+			require.NoError(t, err)
+
+			// the blob carries the key and a signature over the STX-prefixed body
+			base := tx.GetBase()
+			assert.Equal(t, xrpTestAddress, base.Account.String())
+			assert.Equal(t, resp.Data["signing_pub_key"], base.SigningPubKey.String())
+			ok, err := data.CheckSignature(tx)
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			hash, _, err := data.Raw(tx)
+			require.NoError(t, err)
+			assert.Equal(t, hash.String(), resp.Data["hash"])
+		})
+	}
+}
+
+func TestXrpSignTx_Invalid(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	createXrpAccount(t, b, storage)
+
+	payment := func(overrides map[string]interface{}) map[string]interface{} {
+		tx := map[string]interface{}{
+			"TransactionType": "Payment",
+			"Account":         xrpTestAddress,
+			"Destination":     "rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3",
+			"Amount":          "1000000",
+			"Fee":             "12",
+			"Sequence":        7,
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(tx, k)
+				continue
+			}
+			tx[k] = v
+		}
+		return tx
+	}
+
+	cases := map[string]map[string]interface{}{
+		"foreign account":   payment(map[string]interface{}{"Account": "rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3"}),
+		"unknown type":      payment(map[string]interface{}{"TransactionType": "Bogus"}),
+		"unknown field":     payment(map[string]interface{}{"Bogus": 1}),
+		"missing fee":       payment(map[string]interface{}{"Fee": nil}),
+		"already signed":    payment(map[string]interface{}{"TxnSignature": "3045"}),
+		"invalid amount":    payment(map[string]interface{}{"Amount": "abc"}),
+		"missing sequence":  payment(map[string]interface{}{"Sequence": nil}),
+		"short account":     payment(map[string]interface{}{"Account": "prrrr"}),
+		"short destination": payment(map[string]interface{}{"Destination": "prrrr"}),
+		"short issuer": payment(map[string]interface{}{
+			"Amount": map[string]interface{}{"currency": "USD", "issuer": "prrrr", "value": "1"},
+		}),
+	}
+	for name, txJSON := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/xrp/svc/sign-tx")
+			req.Storage = storage
+			req.Data = map[string]interface{}{
+				"address": xrpTestAddress,
+				"tx_json": txJSON,
+			}
+			_, err := b.HandleRequest(context.Background(), req)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestXrpSignTx_Ed25519(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"private_key": "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r"}
+	_, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/xrp/svc/sign-tx")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address": "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD",
+		"tx_json": map[string]interface{}{
+			"TransactionType": "Payment",
+			"Account":         "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD",
+			"Destination":     xrpTestAddress,
+			"Amount":          "1000000",
+			"Fee":             "12",
+			"Sequence":        1,
+		},
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "ED01FA53FA5A7E77798F882ECE20B1ABC00BB358A9E55A202D0D0676BD0CE37A63", resp.Data["signing_pub_key"])
+
+	blob, err := hex.DecodeString(resp.Data["tx_blob"].(string))
+	require.NoError(t, err)
+	tx, err := data.ReadTransaction(bytes.NewReader(blob))
+	require.NoError(t, err)
+
+	// ed25519 signs "STX\0" || body itself; data.CheckSignature omits the prefix for ed25519
+	_, msg, err := data.SigningHash(tx)
+	require.NoError(t, err)
+	pub := tx.GetPublicKey().Bytes()
+	assert.True(t, ed25519.Verify(pub[1:], append(tx.SigningPrefix().Bytes(), msg...), tx.GetSignature().Bytes()))
+}
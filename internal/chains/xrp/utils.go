@@ -1,11 +1,134 @@
 package xrp
 
 import (
+	"bytes"
+	"crypto/ed25519"
 	"crypto/sha256"
-	"golang.org/x/crypto/ripemd160"
+	"encoding/hex"
+	"fmt"
 	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/dsshard/vault-crypto-adapters/internal/types"
+	ripplecrypto "github.com/rubblelabs/ripple/crypto"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// hdPathFormat — BIP-44 путь для XRP (coin type 144), %d заменяется индексом адреса
+const hdPathFormat = "m/44'/144'/0'/0/%d"
+
+// hdPathFormatEd25519 — тот же путь для ed25519: SLIP-0010 допускает только hardened-индексы
+const hdPathFormatEd25519 = "m/44'/144'/0'/0'/%d'"
+
+// Key types of an XRPL account
+const (
+	KeyTypeSecp256k1 = "secp256k1"
+	KeyTypeEd25519   = "ed25519"
+)
+
+// ed25519PublicKeyPrefix — XRPL помечает ed25519 ключи байтом 0xED
+const ed25519PublicKeyPrefix = 0xED
+
+var (
+	// familySeedPrefix / ed25519SeedPrefix — версии base58 family seed: s… (secp256k1) и sEd… (ed25519)
+	familySeedPrefix  = []byte{0x21}
+	ed25519SeedPrefix = []byte{0x01, 0xE1, 0x4B}
 )
 
+// familySeedLen — энтропия family seed, 16 байт
+const familySeedLen = 16
+
+// keyType — тип ключа пары; записи без key_type созданы до поддержки ed25519
+func keyType(t string) string {
+	if t == "" {
+		return KeyTypeSecp256k1
+	}
+	return t
+}
+
+func parseKeyType(t string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(t)) {
+	case "", KeyTypeSecp256k1:
+		return KeyTypeSecp256k1, nil
+	case KeyTypeEd25519:
+		return KeyTypeEd25519, nil
+	default:
+		return "", fmt.Errorf("unsupported key_type %q", t)
+	}
+}
+
+// decodeFamilySeed разбирает XRPL family seed; для sEd… тип ключа определяется самим seed
+func decodeFamilySeed(seed string) (entropy []byte, seedKeyType string, err error) {
+	payload, err := decodeBase58Check(seed)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid family seed: %w", err)
+	}
+	switch {
+	case len(payload) == len(ed25519SeedPrefix)+familySeedLen && bytes.HasPrefix(payload, ed25519SeedPrefix):
+		return payload[len(ed25519SeedPrefix):], KeyTypeEd25519, nil
+	case len(payload) == len(familySeedPrefix)+familySeedLen && bytes.HasPrefix(payload, familySeedPrefix):
+		return payload[len(familySeedPrefix):], "", nil
+	default:
+		return nil, "", fmt.Errorf("invalid family seed")
+	}
+}
+
+// privateKeyFromFamilySeed выводит ключ аккаунта как rippled / xrpl.js:
+// secp256k1 — root generator + ключ аккаунта 0, ed25519 — SHA512Half(seed)
+func privateKeyFromFamilySeed(entropy []byte, kt string) ([]byte, error) {
+	if kt == KeyTypeEd25519 {
+		return ripplecrypto.Sha512Half(entropy)[:ed25519.SeedSize], nil
+	}
+	key, err := ripplecrypto.NewECDSAKey(entropy)
+	if err != nil {
+		return nil, err
+	}
+	var account uint32
+	return key.Private(&account), nil
+}
+
+// publicKey возвращает публичный ключ XRPL: 33 байта compressed secp256k1 или 0xED || ed25519 pub
+func publicKey(priv []byte, kt string) []byte {
+	if kt == KeyTypeEd25519 {
+		pub := ed25519.NewKeyFromSeed(priv).Public().(ed25519.PublicKey)
+		return append([]byte{ed25519PublicKeyPrefix}, pub...)
+	}
+	_, pub := btcec.PrivKeyFromBytes(priv)
+	return pub.SerializeCompressed()
+}
+
+// signingKey — crypto.Key поверх хранимого ключа пары (sequence не используется: ключ аккаунта уже выведен)
+type signingKey struct {
+	private []byte
+	public  []byte
+}
+
+func newSigningKey(kp *types.KeyPair) (*signingKey, error) {
+	privBytes, err := hex.DecodeString(kp.PrivateKey)
+	if err != nil || len(privBytes) != btcec.PrivKeyBytesLen {
+		return nil, fmt.Errorf("stored privateKey is invalid")
+	}
+	kt := keyType(kp.KeyType)
+	key := &signingKey{public: publicKey(privBytes, kt)}
+	if kt == KeyTypeEd25519 {
+		// crypto.Sign различает кривые по длине ключа: 64 байта — ed25519
+		key.private = ed25519.NewKeyFromSeed(privBytes)
+		zeroSeed(privBytes)
+	} else {
+		key.private = privBytes
+	}
+	return key, nil
+}
+
+func (k *signingKey) Private(*uint32) []byte { return k.private }
+func (k *signingKey) Public(*uint32) []byte  { return k.public }
+func (k *signingKey) Id(*uint32) []byte      { return accountID(k.public) }
+
+func (k *signingKey) zero() {
+	zeroSeed(k.private)
+}
+
 // base58Encode — простая реализация Base58Check с кастомным алфавитом.
 func Base58Encode(data []byte, alphabet string) string {
 	// convert big-endian bytes to big integer
@@ -33,18 +156,11 @@ func Base58Encode(data []byte, alphabet string) string {
 	return string(result)
 }
 
-// deriveClassicXRPAddress returns a Ripple “classic” address (starts with r)
+// DeriveClassicXRPAddress returns a Ripple “classic” address (starts with r)
+// for a 33-byte public key: compressed secp256k1 or ED-prefixed ed25519.
 func DeriveClassicXRPAddress(pub33 []byte) string {
-	// SHA256 → RIPEMD160
-	h1 := sha256.Sum256(pub33)
-	// ignore
-	//nolint:gosec
-	rip := ripemd160.New()
-	rip.Write(h1[:])
-	accountID := rip.Sum(nil) // 20 байт
-
 	// prepend version byte
-	versioned := append([]byte{0x00}, accountID...)
+	versioned := append([]byte{0x00}, accountID(pub33)...)
 
 	// checksum = first 4 от double SHA256
 	cs1 := sha256.Sum256(versioned)
@@ -55,3 +171,13 @@ func DeriveClassicXRPAddress(pub33 []byte) string {
 	// 5) Base58 encode with Ripple’s alphabet
 	return Base58Encode(append(versioned, cs2[:4]...), ALPHABET)
 }
+
+// accountID — SHA256 → RIPEMD160 публичного ключа (20 байт)
+func accountID(pub []byte) []byte {
+	h1 := sha256.Sum256(pub)
+	// ignore
+	//nolint:gosec
+	rip := ripemd160.New()
+	rip.Write(h1[:])
+	return rip.Sum(nil)
+}
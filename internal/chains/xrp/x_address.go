@@ -0,0 +1,224 @@
+package xrp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/config"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	ripplecrypto "github.com/rubblelabs/ripple/crypto"
+)
+
+var (
+	// xAddressMainnetPrefix / xAddressTestnetPrefix — версии X-address (XLS-5d): X… и T…
+	xAddressMainnetPrefix = []byte{0x05, 0x44}
+	xAddressTestnetPrefix = []byte{0x04, 0x93}
+)
+
+// classicAddressPrefix — версия classic-адреса (r…)
+const classicAddressPrefix = 0x00
+
+// xAddressPayloadLen — prefix(2) + account id(20) + flag(1) + tag(4, LE) + reserved(4)
+const xAddressPayloadLen = 31
+
+var xAddressFields = map[string]*framework.FieldSchema{
+	"name": {Type: framework.TypeString},
+	"address": {
+		Type:        framework.TypeString,
+		Description: "Classic address (r…) to encode.",
+	},
+	"destination_tag": {
+		Type:        framework.TypeInt,
+		Description: "(Optional) Destination tag to embed into the X-address.",
+	},
+	"testnet": {
+		Type:        framework.TypeBool,
+		Description: "(Optional) Encode a testnet X-address (T…).",
+		Default:     false,
+	},
+	"x_address": {
+		Type:        framework.TypeString,
+		Description: "X-address (X… or T…) to decode into classic address and tag.",
+	},
+}
+
+func PathXAddress() *framework.Path {
+	return &framework.Path{
+		Pattern: config.CreatePathXAddress(config.Chain.XRP),
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback: xAddress,
+			},
+		},
+		HelpSynopsis:    "Encode or decode XRPL X-addresses (XLS-5d).",
+		HelpDescription: "POST address [+ destination_tag, testnet] → x_address; POST x_address → address, destination_tag and testnet.",
+		Fields:          xAddressFields,
+	}
+}
+
+func xAddress(
+	_ context.Context,
+	_ *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	if encoded := strings.TrimSpace(data.Get("x_address").(string)); encoded != "" {
+		classic, tag, testnet, err := DecodeXAddress(encoded)
+		if err != nil {
+			return nil, err
+		}
+		respData := map[string]interface{}{
+			"address":   classic,
+			"testnet":   testnet,
+			"x_address": encoded,
+		}
+		if tag != nil {
+			respData["destination_tag"] = *tag
+		}
+		return &logical.Response{Data: respData}, nil
+	}
+
+	classic := strings.TrimSpace(data.Get("address").(string))
+	if classic == "" {
+		return nil, fmt.Errorf("either address or x_address is required")
+	}
+	tag, err := destinationTag(data)
+	if err != nil {
+		return nil, err
+	}
+	testnet := data.Get("testnet").(bool)
+	encoded, err := EncodeXAddress(classic, tag, testnet)
+	if err != nil {
+		return nil, err
+	}
+	respData := map[string]interface{}{
+		"address":   classic,
+		"testnet":   testnet,
+		"x_address": encoded,
+	}
+	if tag != nil {
+		respData["destination_tag"] = *tag
+	}
+	return &logical.Response{Data: respData}, nil
+}
+
+// destinationTag читает необязательный destination_tag; nil — тег не передан
+func destinationTag(data *framework.FieldData) (*uint32, error) {
+	raw, ok := data.GetOk("destination_tag")
+	if !ok {
+		return nil, nil
+	}
+	v := raw.(int)
+	if v < 0 || v > math.MaxUint32 {
+		return nil, fmt.Errorf("destination_tag must be between 0 and %d", uint32(math.MaxUint32))
+	}
+	tag := uint32(v)
+	return &tag, nil
+}
+
+// EncodeXAddress packs a classic address and an optional destination tag into an X-address (XLS-5d).
+func EncodeXAddress(classic string, tag *uint32, testnet bool) (string, error) {
+	id, err := decodeClassicAddress(classic)
+	if err != nil {
+		return "", err
+	}
+	payload := make([]byte, 0, xAddressPayloadLen)
+	if testnet {
+		payload = append(payload, xAddressTestnetPrefix...)
+	} else {
+		payload = append(payload, xAddressMainnetPrefix...)
+	}
+	payload = append(payload, id...)
+	if tag != nil {
+		payload = append(payload, 1)
+		payload = binary.LittleEndian.AppendUint32(payload, *tag)
+	} else {
+		payload = append(payload, 0, 0, 0, 0, 0)
+	}
+	// зарезервированные старшие 32 бита 64-битного тега
+	payload = append(payload, 0, 0, 0, 0)
+	return ripplecrypto.Base58Encode(payload, ripplecrypto.ALPHABET), nil
+}
+
+// DecodeXAddress returns the classic address, destination tag (nil when absent) and network of an X-address.
+func DecodeXAddress(encoded string) (classic string, tag *uint32, testnet bool, err error) {
+	payload, err := decodeBase58Check(encoded)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("invalid x_address: %w", err)
+	}
+	if len(payload) != xAddressPayloadLen {
+		return "", nil, false, fmt.Errorf("invalid x_address length")
+	}
+	switch {
+	case bytes.HasPrefix(payload, xAddressMainnetPrefix):
+	case bytes.HasPrefix(payload, xAddressTestnetPrefix):
+		testnet = true
+	default:
+		return "", nil, false, fmt.Errorf("invalid x_address prefix")
+	}
+
+	id := payload[2:22]
+	flag := payload[22]
+	tagValue := binary.LittleEndian.Uint32(payload[23:27])
+	reserved := binary.LittleEndian.Uint32(payload[27:31])
+	switch {
+	case reserved != 0:
+		return "", nil, false, fmt.Errorf("unsupported 64-bit destination tag")
+	case flag == 1:
+		tag = &tagValue
+	case flag == 0 && tagValue == 0:
+	default:
+		return "", nil, false, fmt.Errorf("invalid x_address tag flag")
+	}
+	return encodeClassicAddress(id), tag, testnet, nil
+}
+
+// decodeClassicAddress возвращает 20-байтовый account id classic-адреса
+func decodeClassicAddress(classic string) ([]byte, error) {
+	payload, err := decodeBase58Check(classic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	if len(payload) != 21 || payload[0] != classicAddressPrefix {
+		return nil, fmt.Errorf("invalid address %q", classic)
+	}
+	return payload[1:], nil
+}
+
+// decodeBase58Check возвращает payload без checksum; Base58Decode паникует, если
+// значение короче самой checksum, поэтому паника превращается в ошибку
+func decodeBase58Check(s string) (payload []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			payload, err = nil, fmt.Errorf("malformed base58 string")
+		}
+	}()
+	decoded, err := ripplecrypto.Base58Decode(s, ripplecrypto.ALPHABET)
+	if err != nil {
+		return nil, err
+	}
+	return decoded[:len(decoded)-4], nil
+}
+
+func encodeClassicAddress(id []byte) string {
+	return ripplecrypto.Base58Encode(append([]byte{classicAddressPrefix}, id...), ripplecrypto.ALPHABET)
+}
+
+// addXAddresses добавляет X-адреса (mainnet и testnet) с тегом к ответу по паре
+func addXAddresses(pair map[string]interface{}, classic string, tag *uint32) error {
+	mainnet, err := EncodeXAddress(classic, tag, false)
+	if err != nil {
+		return err
+	}
+	testnet, err := EncodeXAddress(classic, tag, true)
+	if err != nil {
+		return err
+	}
+	pair["x_address"] = mainnet
+	pair["x_address_testnet"] = testnet
+	return nil
+}
@@ -0,0 +1,117 @@
+package xrp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/chains/xrp"
+	"github.com/dsshard/vault-crypto-adapters/internal/test"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXrpXAddressVectors(t *testing.T) {
+	one := uint32(1)
+	cases := []struct {
+		tag      *uint32
+		testnet  bool
+		xAddress string
+	}{
+		{nil, false, "X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ"},
+		{&one, false, "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGZMhc9YTE92ehJ2Fu"},
+		{nil, true, "T719a5UwUCnEs54UsxG9CJYYDhwmFCqkr7wxCcNcfZ6p5GZ"},
+		{&one, true, "T719a5UwUCnEs54UsxG9CJYYDhwmFCvbJNZbi37gBGkRkbE"},
+	}
+	for _, tc := range cases {
+		encoded, err := xrp.EncodeXAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", tc.tag, tc.testnet)
+		require.NoError(t, err)
+		assert.Equal(t, tc.xAddress, encoded)
+
+		classic, tag, testnet, err := xrp.DecodeXAddress(tc.xAddress)
+		require.NoError(t, err)
+		assert.Equal(t, "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", classic)
+		assert.Equal(t, tc.tag, tag)
+		assert.Equal(t, tc.testnet, testnet)
+	}
+}
+
+func TestXrpXAddressEndpoint(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "key-managers/xrp/svc/x-address")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"address":         "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+		"destination_tag": 1,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGZMhc9YTE92ehJ2Fu", resp.Data["x_address"])
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/xrp/svc/x-address")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"x_address": "T719a5UwUCnEs54UsxG9CJYYDhwmFCqkr7wxCcNcfZ6p5GZ"}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", resp.Data["address"])
+	assert.Equal(t, true, resp.Data["testnet"])
+	assert.NotContains(t, resp.Data, "destination_tag")
+
+	for _, data := range []map[string]interface{}{
+		{},
+		{"address": "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk58"},
+		{"address": "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", "destination_tag": -1},
+		{"x_address": "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGZMhc9YTE92ehJ2Fv"},
+		// короткие и мусорные строки не должны ронять обработчик
+		{"x_address": "prrrr"},
+		{"x_address": "r"},
+		{"x_address": "X7Acg0OIl"},
+		{"address": "prrrr"},
+		{"address": "rrrrr"},
+		{"address": "not an address"},
+	} {
+		req = logical.TestRequest(t, logical.UpdateOperation, "key-managers/xrp/svc/x-address")
+		req.Storage = storage
+		req.Data = data
+		_, err = b.HandleRequest(context.Background(), req)
+		require.Error(t, err)
+	}
+}
+
+func TestXrpCreateAndRead_XAddress(t *testing.T) {
+	b, storage := test.NewTestBackend(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{
+		"private_key":     "90dc3e2382d825f290148356dbbe315135dc0fe60bb17030edd2ea6127f938d5",
+		"destination_tag": 12345,
+	}
+	resp, err := b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	xAddress := resp.Data["x_address"].(string)
+	assert.Regexp(t, `^X`, xAddress)
+	assert.Regexp(t, `^T`, resp.Data["x_address_testnet"])
+
+	classic, tag, _, err := xrp.DecodeXAddress(xAddress)
+	require.NoError(t, err)
+	assert.Equal(t, xrpTestAddress, classic)
+	assert.Equal(t, uint32(12345), *tag)
+
+	// read without a tag keeps classic addresses only
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pairs := resp.Data["key_pairs"].([]map[string]interface{})
+	assert.NotContains(t, pairs[0], "x_address")
+
+	req = logical.TestRequest(t, logical.ReadOperation, "key-managers/xrp/svc")
+	req.Storage = storage
+	req.Data = map[string]interface{}{"destination_tag": 12345}
+	resp, err = b.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	pairs = resp.Data["key_pairs"].([]map[string]interface{})
+	assert.Equal(t, xAddress, pairs[0]["x_address"])
+}
@@ -10,6 +10,20 @@ var Chain = struct {
 	SOL  ChainType
 	XRP  ChainType
 	DOGE ChainType
+	LTC  ChainType
+	ADA  ChainType
+	DOT  ChainType
+	APT  ChainType
+	SUI  ChainType
+	XLM  ChainType
+
+	COSMOS ChainType
+
+	POLYGON   ChainType
+	BSC       ChainType
+	ARBITRUM  ChainType
+	BASE      ChainType
+	AVALANCHE ChainType
 }{
 	BTC:  "btc",
 	ETH:  "eth",
@@ -18,6 +32,20 @@ var Chain = struct {
 	SOL:  "sol",
 	XRP:  "xrp",
 	DOGE: "doge",
+	LTC:  "ltc",
+	ADA:  "ada",
+	DOT:  "dot",
+	APT:  "apt",
+	SUI:  "sui",
+	XLM:  "xlm",
+
+	COSMOS: "cosmos",
+
+	POLYGON:   "polygon",
+	BSC:       "bsc",
+	ARBITRUM:  "arbitrum",
+	BASE:      "base",
+	AVALANCHE: "avalanche",
 }
 
 // AllChains — упорядоченный список всех поддерживаемых ChainType
@@ -29,4 +57,35 @@ var AllChains = []ChainType{
 	Chain.SOL,
 	Chain.XRP,
 	Chain.DOGE,
+	Chain.LTC,
+	Chain.COSMOS,
+	Chain.ADA,
+	Chain.DOT,
+	Chain.APT,
+	Chain.SUI,
+	Chain.XLM,
+	Chain.POLYGON,
+	Chain.BSC,
+	Chain.ARBITRUM,
+	Chain.BASE,
+	Chain.AVALANCHE,
+}
+
+// EVMChains — монеты, которые обслуживает код eth; у каждой свой префикс хранилища и chain id
+var EVMChains = []ChainType{
+	Chain.ETH,
+	Chain.POLYGON,
+	Chain.BSC,
+	Chain.ARBITRUM,
+	Chain.BASE,
+	Chain.AVALANCHE,
+}
+
+// EVMChainIDs — EIP-155 chain id по умолчанию для sign-tx; у eth его нет, chain_id обязателен
+var EVMChainIDs = map[ChainType]uint64{
+	Chain.POLYGON:   137,
+	Chain.BSC:       56,
+	Chain.ARBITRUM:  42161,
+	Chain.BASE:      8453,
+	Chain.AVALANCHE: 43114,
 }
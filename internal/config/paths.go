@@ -9,6 +9,18 @@ func GetStoragePath(chain ChainType, service string) string {
 	return fmt.Sprintf("key-managers/%s/%s", chain, service)
 }
 
+func GetConfigStoragePath(chain ChainType) string {
+	return fmt.Sprintf("config/%s", chain)
+}
+
+func CreatePathConfig() string {
+	return "config/?"
+}
+
+func CreatePathConfigChain(chain ChainType) string {
+	return fmt.Sprintf("config/%s", chain)
+}
+
 func CreatePathCrud(chain ChainType) string {
 	return fmt.Sprintf("key-managers/%s/%s", chain, framework.GenericNameRegex("name"))
 }
@@ -24,3 +36,27 @@ func CreatePathUpdateExternalData(chain ChainType) string {
 func CreatePathSign(chain ChainType) string {
 	return fmt.Sprintf("key-managers/%s/%s/sign", chain, framework.GenericNameRegex("name"))
 }
+
+func CreatePathSignTx(chain ChainType) string {
+	return fmt.Sprintf("key-managers/%s/%s/sign-tx", chain, framework.GenericNameRegex("name"))
+}
+
+func CreatePathSignMessage(chain ChainType) string {
+	return fmt.Sprintf("key-managers/%s/%s/sign-message", chain, framework.GenericNameRegex("name"))
+}
+
+func CreatePathSignTypedData(chain ChainType) string {
+	return fmt.Sprintf("key-managers/%s/%s/sign-typed-data", chain, framework.GenericNameRegex("name"))
+}
+
+func CreatePathSignPsbt(chain ChainType) string {
+	return fmt.Sprintf("key-managers/%s/%s/sign-psbt", chain, framework.GenericNameRegex("name"))
+}
+
+func CreatePathSignTransfer(chain ChainType) string {
+	return fmt.Sprintf("key-managers/%s/%s/sign-transfer", chain, framework.GenericNameRegex("name"))
+}
+
+func CreatePathXAddress(chain ChainType) string {
+	return fmt.Sprintf("key-managers/%s/%s/x-address", chain, framework.GenericNameRegex("name"))
+}
@@ -0,0 +1,114 @@
+package hd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// mnemonicEntropyBits — 256 бит энтропии дают мнемонику из 24 слов
+const mnemonicEntropyBits = 256
+
+var (
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	ErrInvalidPath     = errors.New("invalid derivation path")
+)
+
+// NewMnemonic generates a fresh 24-word BIP-39 mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NormalizeMnemonic collapses whitespace and validates the BIP-39 checksum.
+func NormalizeMnemonic(mnemonic string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+	if !bip39.IsMnemonicValid(normalized) {
+		return "", ErrInvalidMnemonic
+	}
+	return normalized, nil
+}
+
+// Seed returns the 64-byte BIP-39 seed for mnemonic and optional passphrase.
+func Seed(mnemonic, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	return seed, nil
+}
+
+// ParsePath parses a path like m/44'/60'/0'/0/1 into BIP-32 child indexes.
+// Both ' and h are accepted as hardened markers.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, ErrInvalidPath
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || n >= hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		index := uint32(n)
+		if hardened {
+			index += hdkeychain.HardenedKeyStart
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// DeriveSecp256k1 derives the 32-byte secp256k1 private key at a BIP-32 path.
+func DeriveSecp256k1(mnemonic, passphrase, path string) ([]byte, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := Seed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(seed)
+
+	// параметры сети влияют только на сериализацию xprv, на сами ключи — нет
+	key, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create master key: %w", err)
+	}
+	for _, index := range indexes {
+		child, err := key.Derive(index)
+		key.Zero()
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive child %d: %w", index, err)
+		}
+		key = child
+	}
+	defer key.Zero()
+
+	priv, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+	return priv.Serialize(), nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
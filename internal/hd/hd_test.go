@@ -0,0 +1,84 @@
+package hd_test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dsshard/vault-crypto-adapters/internal/hd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	indexes, err := hd.ParsePath("m/44'/60'/0h/0/7")
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{0x8000002c, 0x8000003c, 0x80000000, 0, 7}, indexes)
+
+	for _, bad := range []string{"", "44'/60'", "m/-1", "m/x'", "m/2147483648"} {
+		_, err := hd.ParsePath(bad)
+		assert.ErrorIs(t, err, hd.ErrInvalidPath, bad)
+	}
+}
+
+func TestNormalizeMnemonic(t *testing.T) {
+	m, err := hd.NormalizeMnemonic("  Abandon abandon abandon abandon abandon abandon\nabandon abandon abandon abandon abandon about ")
+	require.NoError(t, err)
+	assert.Equal(t, "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", m)
+
+	_, err = hd.NormalizeMnemonic("abandon abandon abandon")
+	assert.ErrorIs(t, err, hd.ErrInvalidMnemonic)
+}
+
+func TestEd25519FromSeed(t *testing.T) {
+	// SLIP-0010 test vector 1 for ed25519
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	key, err := hd.Ed25519FromSeed(seed, "m")
+	require.NoError(t, err)
+	assert.Equal(t, "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", hex.EncodeToString(key))
+
+	key, err = hd.Ed25519FromSeed(seed, "m/0'/1'/2'/2'/1000000000'")
+	require.NoError(t, err)
+	assert.Equal(t, "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793", hex.EncodeToString(key))
+
+	_, err = hd.Ed25519FromSeed(seed, "m/0'/1")
+	assert.ErrorIs(t, err, hd.ErrInvalidPath)
+}
+
+func TestDeriveIcarus(t *testing.T) {
+	// CIP-3 Icarus master key (kL || kR, без chain code)
+	key, err := hd.DeriveIcarus("eight country switch draw meat scout mystery blade tip drift useless good keep usage title", "", "m")
+	require.NoError(t, err)
+	assert.Equal(t, "c065afd2832cd8b087c4d9ab7011f481ee1e0721e78ea5dd609f3ab3f156d245d176bd8fd4ec60b4731c3918a2a72a0226c0cd119ec35b47e4d55884667f552a", hex.EncodeToString(key))
+
+	// CIP-19 payment key: addr_vk1w0l2sr2zgfm26ztc6nl9xy8ghsk5sh6ldwemlpmp9xylzy4dtf7st80zhd
+	key, err = hd.DeriveIcarus("test walk nut penalty hip pave soap entry language right filter choice", "", "m/1852'/1815'/0'/0/0")
+	require.NoError(t, err)
+	assert.Equal(t, "73fea80d424276ad0978d4fe5310e8bc2d485f5f6bb3bf87612989f112ad5a7d", hex.EncodeToString(hd.Ed25519ExtendedPublicKey(key[:32])))
+
+	_, err = hd.DeriveIcarus("abandon abandon abandon", "", "m")
+	assert.ErrorIs(t, err, hd.ErrInvalidMnemonic)
+}
+
+func TestDeriveSubstrate(t *testing.T) {
+	// Substrate dev phrase: корень и //Alice (subkey inspect)
+	const devPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+	secret, err := hd.DeriveSr25519(devPhrase, "", "//Alice")
+	require.NoError(t, err)
+	assert.Equal(t, "e5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a", hex.EncodeToString(secret))
+
+	seed, err := hd.DeriveSubstrateEd25519(devPhrase, "", "//Alice")
+	require.NoError(t, err)
+	assert.Equal(t, "88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee", hex.EncodeToString(ed25519.NewKeyFromSeed(seed)[32:]))
+
+	root, err := hd.DeriveSr25519(devPhrase, "", "")
+	require.NoError(t, err)
+	assert.Len(t, root, 32)
+
+	for _, bad := range []string{"/0", "//", "//a/0", "m/0"} {
+		_, err := hd.DeriveSr25519(devPhrase, "", bad)
+		assert.ErrorIs(t, err, hd.ErrInvalidPath, bad)
+	}
+}
@@ -0,0 +1,103 @@
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+
+	"filippo.io/edwards25519"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// icarusIterations — число итераций PBKDF2 мастер-ключа Icarus (CIP-3)
+const icarusIterations = 4096
+
+// DeriveIcarus derives the 64-byte extended ed25519 key kL || kR at a BIP32-Ed25519 path
+// from a BIP-39 mnemonic, with the Icarus master key (CIP-3) used by Cardano wallets.
+func DeriveIcarus(mnemonic, passphrase, path string) ([]byte, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	defer zero(entropy)
+
+	// master = PBKDF2-HMAC-SHA512(passphrase, entropy) → kL || kR || chain code, kL очищается по ed25519
+	node := pbkdf2.Key([]byte(passphrase), entropy, icarusIterations, 96, sha512.New)
+	defer zero(node)
+	node[0] &= 0xf8
+	node[31] &= 0x1f
+	node[31] |= 0x40
+
+	for _, index := range indexes {
+		child := icarusChild(node, index)
+		zero(node)
+		node = child
+	}
+
+	key := make([]byte, 64)
+	copy(key, node[:64])
+	return key, nil
+}
+
+// Ed25519ExtendedPublicKey returns A = kL·B for the left half of an extended key; kL is used as is, without hashing.
+func Ed25519ExtendedPublicKey(kL []byte) []byte {
+	wide := make([]byte, 64)
+	copy(wide, kL[:32])
+	s, _ := edwards25519.NewScalar().SetUniformBytes(wide)
+	zero(wide)
+	return new(edwards25519.Point).ScalarBaseMult(s).Bytes()
+}
+
+// icarusChild — дочерний узел BIP32-Ed25519 (V2): node = kL || kR || chain code
+func icarusChild(node []byte, index uint32) []byte {
+	kL, kR, chainCode := node[:32], node[32:64], node[64:96]
+
+	// hardened: 0x00 || kL || kR || index, обычный: 0x02 || A || index; для chain code — 0x01 / 0x03
+	var data []byte
+	zTag, ccTag := byte(0x02), byte(0x03)
+	if index >= hdkeychain.HardenedKeyStart {
+		zTag, ccTag = 0x00, 0x01
+		data = append(append(data, kL...), kR...)
+	} else {
+		data = Ed25519ExtendedPublicKey(kL)
+	}
+	data = binary.LittleEndian.AppendUint32(data, index)
+	defer zero(data)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write([]byte{zTag})
+	mac.Write(data)
+	z := mac.Sum(nil)
+	defer zero(z)
+
+	mac = hmac.New(sha512.New, chainCode)
+	mac.Write([]byte{ccTag})
+	mac.Write(data)
+	cc := mac.Sum(nil)
+
+	child := make([]byte, 96)
+	// kL' = kL + 8·zL[:28], kR' = kR + zR (mod 2^256), little-endian
+	var carry uint16
+	for i := 0; i < 32; i++ {
+		sum := uint16(kL[i]) + carry
+		if i < 28 {
+			sum += uint16(z[i]) << 3
+		}
+		child[i] = byte(sum)
+		carry = sum >> 8
+	}
+	carry = 0
+	for i := 0; i < 32; i++ {
+		sum := uint16(kR[i]) + uint16(z[32+i]) + carry
+		child[32+i] = byte(sum)
+		carry = sum >> 8
+	}
+	copy(child[64:], cc[32:])
+	return child
+}
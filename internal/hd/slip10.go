@@ -0,0 +1,58 @@
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+// slip10Ed25519Key — HMAC-ключ мастер-узла для кривой ed25519 (SLIP-0010)
+var slip10Ed25519Key = []byte("ed25519 seed")
+
+// DeriveEd25519 derives the 32-byte ed25519 seed at a SLIP-0010 path from a BIP-39 mnemonic.
+func DeriveEd25519(mnemonic, passphrase, path string) ([]byte, error) {
+	seed, err := Seed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(seed)
+	return Ed25519FromSeed(seed, path)
+}
+
+// Ed25519FromSeed derives the 32-byte ed25519 seed at a SLIP-0010 path from a raw master seed.
+// ed25519 supports hardened derivation only, so every path segment must be hardened.
+func Ed25519FromSeed(seed []byte, path string) ([]byte, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha512.New, slip10Ed25519Key)
+	mac.Write(seed)
+	node := mac.Sum(nil)
+	defer zero(node)
+
+	for _, index := range indexes {
+		if index < hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("%w: ed25519 supports hardened indexes only: %q", ErrInvalidPath, path)
+		}
+		// I = HMAC-SHA512(chain code, 0x00 || key || ser32(index))
+		data := make([]byte, 0, 37)
+		data = append(data, 0x00)
+		data = append(data, node[:32]...)
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac = hmac.New(sha512.New, node[32:])
+		mac.Write(data)
+		zero(data)
+		zero(node)
+		node = mac.Sum(nil)
+	}
+
+	key := make([]byte, 32)
+	copy(key, node[:32])
+	return key, nil
+}
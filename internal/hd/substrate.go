@@ -0,0 +1,134 @@
+package hd
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/merlin"
+	"github.com/oasisprotocol/curve25519-voi/primitives/sr25519"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// substrateIterations — PBKDF2 в substrate-bip39: ключом служит энтропия мнемоники, а не её текст
+const substrateIterations = 2048
+
+// ed25519HDKDPrefix — SCALE-строка "Ed25519HDKD" (compact длина 11 << 2 = 0x2c)
+var ed25519HDKDPrefix = append([]byte{0x2c}, "Ed25519HDKD"...)
+
+// DeriveSr25519 derives the 32-byte sr25519 mini secret key at a Substrate path of hard
+// junctions (//polkadot//0) from a BIP-39 mnemonic, as polkadot.js and subkey do.
+func DeriveSr25519(mnemonic, passphrase, path string) ([]byte, error) {
+	junctions, err := ParseSubstratePath(path)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := substrateMiniSecret(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cc := range junctions {
+		msk, _ := sr25519.NewMiniSecretKeyFromBytes(secret)
+		key, _ := msk.ExpandEd25519().MarshalBinary()
+		zero(secret)
+
+		// schnorrkel hard_derive_mini_secret_key(chain code, b"")
+		t := merlin.NewTranscript("SchnorrRistrettoHDKD")
+		t.AppendMessage("sign-bytes", nil)
+		t.AppendMessage("chain-code", cc[:])
+		t.AppendMessage("secret-key", key[:sr25519.SecretKeyScalarSize])
+		zero(key)
+		secret = make([]byte, sr25519.MiniSecretKeySize)
+		t.ExtractBytes(secret, "HDKD-hard")
+	}
+	return secret, nil
+}
+
+// DeriveSubstrateEd25519 derives the 32-byte ed25519 seed at a Substrate path of hard junctions.
+func DeriveSubstrateEd25519(mnemonic, passphrase, path string) ([]byte, error) {
+	junctions, err := ParseSubstratePath(path)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := substrateMiniSecret(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cc := range junctions {
+		// blake2_256(("Ed25519HDKD", seed, chain code).encode())
+		h, _ := blake2b.New256(nil)
+		h.Write(ed25519HDKDPrefix)
+		h.Write(seed)
+		h.Write(cc[:])
+		zero(seed)
+		seed = h.Sum(nil)
+	}
+	return seed, nil
+}
+
+// ParseSubstratePath parses a path like //polkadot//0 into 32-byte junction chain codes.
+// Only hard junctions are accepted; an empty path is the root key.
+func ParseSubstratePath(path string) ([][32]byte, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "//") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+
+	parts := strings.Split(path[2:], "//")
+	junctions := make([][32]byte, 0, len(parts))
+	for _, part := range parts {
+		if part == "" || strings.Contains(part, "/") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		// число кодируется как u64 LE, остальное — SCALE-строкой; длиннее 32 байт — blake2_256
+		var encoded []byte
+		if n, err := strconv.ParseUint(part, 10, 64); err == nil {
+			encoded = binary.LittleEndian.AppendUint64(nil, n)
+		} else {
+			encoded = append(compactLength(len(part)), part...)
+		}
+		var cc [32]byte
+		if len(encoded) > len(cc) {
+			cc = blake2b.Sum256(encoded)
+		} else {
+			copy(cc[:], encoded)
+		}
+		junctions = append(junctions, cc)
+	}
+	return junctions, nil
+}
+
+// substrateMiniSecret — первые 32 байта PBKDF2-HMAC-SHA512(энтропия, "mnemonic" + passphrase)
+func substrateMiniSecret(mnemonic, passphrase string) ([]byte, error) {
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	defer zero(entropy)
+
+	seed := pbkdf2.Key(entropy, []byte("mnemonic"+passphrase), substrateIterations, 64, sha512.New)
+	secret := make([]byte, 32)
+	copy(secret, seed)
+	zero(seed)
+	return secret, nil
+}
+
+// compactLength — SCALE compact-кодирование длины строки
+func compactLength(n int) []byte {
+	switch {
+	case n < 1<<6:
+		return []byte{byte(n) << 2}
+	case n < 1<<14:
+		return binary.LittleEndian.AppendUint16(nil, uint16(n)<<2|0x01)
+	}
+	return binary.LittleEndian.AppendUint32(nil, uint32(n)<<2|0x02)
+}
@@ -11,11 +11,35 @@ type KeyPair struct {
 	Address            string                 `json:"address"`
 	ExternalData       map[string]interface{} `json:"external_data,omitempty"`
 	IsLockExternalData bool                   `json:"is_lock_external_data,omitempty"`
+	DerivationPath     string                 `json:"derivation_path,omitempty"`
+	DerivationIndex    *uint32                `json:"derivation_index,omitempty"`
+	AddressType        string                 `json:"address_type,omitempty"`
+	AddressMode        string                 `json:"address_mode,omitempty"`
+	WalletVersion      string                 `json:"wallet_version,omitempty"`
+	Workchain          *int32                 `json:"workchain,omitempty"`
+	KeyType            string                 `json:"key_type,omitempty"`
+	SubwalletID        *uint32                `json:"subwallet_id,omitempty"`
+	Bounceable         *bool                  `json:"bounceable,omitempty"`
+	Testnet            bool                   `json:"testnet,omitempty"`
+	HighloadTimeout    uint32                 `json:"highload_timeout,omitempty"`
 }
 
 type KeyManager struct {
 	ServiceName string     `json:"service_name"`
 	KeyPairs    []*KeyPair `json:"key_pairs"`
+	Mnemonic    string     `json:"mnemonic,omitempty"`
+	Passphrase  string     `json:"passphrase,omitempty"`
+	Network     string     `json:"network,omitempty"`
+	HRP         string     `json:"hrp,omitempty"`
+}
+
+// ChainConfig — mount-level настройки монеты по умолчанию, хранятся в config/<chain>
+type ChainConfig struct {
+	Network       string `json:"network,omitempty"`
+	AddressType   string `json:"address_type,omitempty"`
+	WalletVersion string `json:"wallet_version,omitempty"`
+	Workchain     *int32 `json:"workchain,omitempty"`
+	ChainID       uint64 `json:"chain_id,omitempty"`
 }
 
 var (